@@ -403,6 +403,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 			mysqlConfig = &config.MySQLConfig{
 				WorkerCount:                  c.Sink.MySQLConfig.WorkerCount,
 				MaxTxnRow:                    c.Sink.MySQLConfig.MaxTxnRow,
+				MaxTxnSize:                   c.Sink.MySQLConfig.MaxTxnSize,
 				MaxMultiUpdateRowSize:        c.Sink.MySQLConfig.MaxMultiUpdateRowSize,
 				MaxMultiUpdateRowCount:       c.Sink.MySQLConfig.MaxMultiUpdateRowCount,
 				TiDBTxnMode:                  c.Sink.MySQLConfig.TiDBTxnMode,
@@ -459,7 +460,8 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 	}
 	if c.Mounter != nil {
 		res.Mounter = &config.MounterConfig{
-			WorkerNum: c.Mounter.WorkerNum,
+			WorkerNum:    c.Mounter.WorkerNum,
+			FullRowImage: c.Mounter.FullRowImage,
 		}
 	}
 	if c.Scheduler != nil {
@@ -646,6 +648,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			mysqlConfig = &MySQLConfig{
 				WorkerCount:                  cloned.Sink.MySQLConfig.WorkerCount,
 				MaxTxnRow:                    cloned.Sink.MySQLConfig.MaxTxnRow,
+				MaxTxnSize:                   cloned.Sink.MySQLConfig.MaxTxnSize,
 				MaxMultiUpdateRowSize:        cloned.Sink.MySQLConfig.MaxMultiUpdateRowSize,
 				MaxMultiUpdateRowCount:       cloned.Sink.MySQLConfig.MaxMultiUpdateRowCount,
 				TiDBTxnMode:                  cloned.Sink.MySQLConfig.TiDBTxnMode,
@@ -741,7 +744,8 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 	}
 	if cloned.Mounter != nil {
 		res.Mounter = &MounterConfig{
-			WorkerNum: cloned.Mounter.WorkerNum,
+			WorkerNum:    cloned.Mounter.WorkerNum,
+			FullRowImage: cloned.Mounter.FullRowImage,
 		}
 	}
 	if cloned.Scheduler != nil {
@@ -778,7 +782,8 @@ type FilterConfig struct {
 
 // MounterConfig represents mounter config for a changefeed
 type MounterConfig struct {
-	WorkerNum int `json:"worker_num"`
+	WorkerNum    int  `json:"worker_num"`
+	FullRowImage bool `json:"full_row_image,omitempty"`
 }
 
 // EventFilterRule is used by sql event filter and expression filter
@@ -1150,6 +1155,7 @@ type KafkaConfig struct {
 type MySQLConfig struct {
 	WorkerCount                  *int    `json:"worker_count,omitempty"`
 	MaxTxnRow                    *int    `json:"max_txn_row,omitempty"`
+	MaxTxnSize                   *int    `json:"max_txn_size,omitempty"`
 	MaxMultiUpdateRowSize        *int    `json:"max_multi_update_row_size,omitempty"`
 	MaxMultiUpdateRowCount       *int    `json:"max_multi_update_row_count,omitempty"`
 	TiDBTxnMode                  *string `json:"tidb_txn_mode,omitempty"`
@@ -113,6 +113,10 @@ func TestToAPIReplicaConfig(t *testing.T) {
 		},
 		SchemaRegistry: util.AddressOf("bbb"),
 		TxnAtomicity:   util.AddressOf(config.AtomicityLevel("aa")),
+		MySQLConfig: &config.MySQLConfig{
+			MaxTxnRow:  util.AddressOf(100),
+			MaxTxnSize: util.AddressOf(1024),
+		},
 	}
 	cfg.Consistent = &config.ConsistentConfig{
 		Level:             "1",
@@ -147,7 +151,7 @@ func TestToAPIReplicaConfig(t *testing.T) {
 			IgnoreDeleteValueExpr:    "age > 20",
 		}},
 	}
-	cfg.Mounter = &config.MounterConfig{WorkerNum: 11}
+	cfg.Mounter = &config.MounterConfig{WorkerNum: 11, FullRowImage: true}
 	cfg.Scheduler = &config.ChangefeedSchedulerConfig{
 		EnableTableAcrossNodes: true, RegionThreshold: 10001, WriteKeyThreshold: 10001,
 	}
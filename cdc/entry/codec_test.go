@@ -14,11 +14,19 @@
 package entry
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/kv"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,6 +56,31 @@ func TestDecodeListData(t *testing.T) {
 	require.Equal(t, list.index, index)
 }
 
+func TestDecodeRowV1UnsignedInteger(t *testing.T) {
+	t.Parallel()
+	ft := types.NewFieldType(mysql.TypeLonglong)
+	ft.AddFlag(mysql.UnsignedFlag)
+	tableInfo := model.WrapTableInfo(1, "test", 0, &timodel.TableInfo{
+		ID:   2,
+		Name: timodel.NewCIStr("t"),
+		Columns: []*timodel.ColumnInfo{
+			{ID: 1, Name: timodel.NewCIStr("a"), FieldType: *ft, State: timodel.StatePublic},
+		},
+	})
+
+	sc := &stmtctx.StatementContext{TimeZone: time.UTC}
+	value, err := tablecodec.EncodeOldRow(sc,
+		[]types.Datum{types.NewUintDatum(math.MaxUint64)}, []int64{1}, nil, nil)
+	require.NoError(t, err)
+	require.False(t, rowcodec.IsNewFormat(value))
+
+	row, err := decodeRowV1(value, tableInfo, time.UTC)
+	require.NoError(t, err)
+	datum := row[1]
+	require.Equal(t, types.KindUint64, datum.Kind())
+	require.Equal(t, uint64(math.MaxUint64), datum.GetUint64())
+}
+
 func buildMetaKey(key []byte, index int64) []byte {
 	ek := make([]byte, 0, len(metaPrefix)+len(key)+36)
 	ek = append(ek, metaPrefix...)
@@ -39,6 +39,21 @@ var (
 			Name:      "group_input_chan_size",
 			Help:      "The size of input channel of mounter group",
 		}, []string{"namespace", "changefeed"})
+	mountDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "mounter",
+			Name:      "mount_duration_seconds",
+			Help:      "Bucketed histogram of the duration of mounting a row kv entry.",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 2, 18), // 10us ~ 1.3s
+		}, []string{"namespace", "changefeed"})
+	mountedRowsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mounter",
+			Name:      "mounted_rows_count",
+			Help:      "The total count of rows mounted by mounter, grouped by type",
+		}, []string{"namespace", "changefeed", "type"}) // type is for `insert`, `update` and `delete`.
 )
 
 // InitMetrics registers all metrics in this file
@@ -46,4 +61,6 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(totalRowsCountGauge)
 	registry.MustRegister(ignoredDMLEventCounter)
 	registry.MustRegister(mounterGroupInputChanSizeGauge)
+	registry.MustRegister(mountDuration)
+	registry.MustRegister(mountedRowsCounter)
 }
@@ -78,8 +78,15 @@ type mounter struct {
 	filter                       pfilter.Filter
 	metricTotalRows              prometheus.Gauge
 	metricIgnoredDMLEventCounter prometheus.Counter
+	metricMountDuration          prometheus.Observer
+	metricInsertRows             prometheus.Counter
+	metricUpdateRows             prometheus.Counter
+	metricDeleteRows             prometheus.Counter
 
 	integrity *integrity.Config
+	// fullRowImage marks the columns of the before image which are not
+	// present in the old value as unknown.
+	fullRowImage bool
 
 	// decoder and preDecoder are used to decode the raw value, also used to extract checksum,
 	// they should not be nil after decode at least one event in the row format v2.
@@ -98,6 +105,7 @@ func NewMounter(schemaStorage SchemaStorage,
 	tz *time.Location,
 	filter pfilter.Filter,
 	integrity *integrity.Config,
+	fullRowImage bool,
 ) Mounter {
 	return &mounter{
 		schemaStorage: schemaStorage,
@@ -107,8 +115,17 @@ func NewMounter(schemaStorage SchemaStorage,
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
 		metricIgnoredDMLEventCounter: ignoredDMLEventCounter.
 			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
-		tz:        tz,
-		integrity: integrity,
+		metricMountDuration: mountDuration.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID),
+		metricInsertRows: mountedRowsCounter.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID, "insert"),
+		metricUpdateRows: mountedRowsCounter.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID, "update"),
+		metricDeleteRows: mountedRowsCounter.
+			WithLabelValues(changefeedID.Namespace, changefeedID.ID, "delete"),
+		tz:           tz,
+		integrity:    integrity,
+		fullRowImage: fullRowImage,
 
 		encoder: &rowcodec.Encoder{},
 		sctx: &stmtctx.StatementContext{
@@ -124,10 +141,23 @@ func (m *mounter) DecodeEvent(ctx context.Context, event *model.PolymorphicEvent
 	if event.IsResolved() {
 		return nil
 	}
+	start := time.Now()
 	row, err := m.unmarshalAndMountRowChanged(ctx, event.RawKV)
+	m.metricMountDuration.Observe(time.Since(start).Seconds())
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Only the rows mounted successfully are counted.
+	if row != nil {
+		switch {
+		case row.IsInsert():
+			m.metricInsertRows.Inc()
+		case row.IsUpdate():
+			m.metricUpdateRows.Inc()
+		case row.IsDelete():
+			m.metricDeleteRows.Inc()
+		}
+	}
 
 	event.Row = row
 	event.RawKV.Value = nil
@@ -520,6 +550,9 @@ func (m *mounter) mountRowKVEntry(tableInfo *model.TableInfo, row *rowKVEntry, d
 			}
 			corrupted = true
 		}
+		if m.fullRowImage {
+			markUnknownColumns(preCols, columnInfos, tableInfo, row.PreRow)
+		}
 	}
 
 	var (
@@ -593,6 +626,27 @@ func (m *mounter) mountRowKVEntry(tableInfo *model.TableInfo, row *rowKVEntry, d
 	}, rawRow, nil
 }
 
+// markUnknownColumns marks the columns not present in the decoded datums as
+// unknown. Their values are filled by datum2Column with the default value of
+// the column, which is only a guess for the before image, so they are reset.
+func markUnknownColumns(
+	cols []*model.Column, columnInfos []*timodel.ColumnInfo,
+	tableInfo *model.TableInfo, datums map[int64]types.Datum,
+) {
+	for _, colInfo := range columnInfos {
+		if colInfo == nil {
+			continue
+		}
+		if _, ok := datums[colInfo.ID]; ok {
+			continue
+		}
+		col := cols[tableInfo.RowColumnsOffset[colInfo.ID]]
+		col.Value = nil
+		col.ApproximateBytes = sizeOfEmptyColumn
+		col.Flag.SetIsUnknownValue()
+	}
+}
+
 var emptyBytes = make([]byte, 0)
 
 const (
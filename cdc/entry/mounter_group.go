@@ -39,6 +39,7 @@ type mounterGroup struct {
 	tz            *time.Location
 	filter        filter.Filter
 	integrity     *integrity.Config
+	fullRowImage  bool
 
 	workerNum int
 
@@ -59,6 +60,7 @@ func NewMounterGroup(
 	tz *time.Location,
 	changefeedID model.ChangeFeedID,
 	integrity *integrity.Config,
+	fullRowImage bool,
 ) *mounterGroup {
 	if workerNum <= 0 {
 		workerNum = defaultMounterWorkerNum
@@ -69,7 +71,8 @@ func NewMounterGroup(
 		filter:        filter,
 		tz:            tz,
 
-		integrity: integrity,
+		integrity:    integrity,
+		fullRowImage: fullRowImage,
 
 		workerNum: workerNum,
 
@@ -80,6 +83,10 @@ func NewMounterGroup(
 func (m *mounterGroup) Run(ctx context.Context, _ ...chan<- error) error {
 	defer func() {
 		mounterGroupInputChanSizeGauge.DeleteLabelValues(m.changefeedID.Namespace, m.changefeedID.ID)
+		mountDuration.DeleteLabelValues(m.changefeedID.Namespace, m.changefeedID.ID)
+		for _, tp := range []string{"insert", "update", "delete"} {
+			mountedRowsCounter.DeleteLabelValues(m.changefeedID.Namespace, m.changefeedID.ID, tp)
+		}
 	}()
 	g, ctx := errgroup.WithContext(ctx)
 	for i := 0; i < m.workerNum; i++ {
@@ -108,7 +115,7 @@ func (m *mounterGroup) WaitForReady(_ context.Context) {}
 func (m *mounterGroup) Close() {}
 
 func (m *mounterGroup) runWorker(ctx context.Context) error {
-	mounter := NewMounter(m.schemaStorage, m.changefeedID, m.tz, m.filter, m.integrity)
+	mounter := NewMounter(m.schemaStorage, m.changefeedID, m.tz, m.filter, m.integrity, m.fullRowImage)
 	for {
 		select {
 		case <-ctx.Done():
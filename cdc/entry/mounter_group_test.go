@@ -0,0 +1,80 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+// countChangefeedMetrics returns the number of metrics of the collector
+// labeled with the changefeed.
+func countChangefeedMetrics(
+	t *testing.T, collector prometheus.Collector, changefeedID model.ChangeFeedID,
+) int {
+	ch := make(chan prometheus.Metric, 16)
+	go func() {
+		collector.Collect(ch)
+		close(ch)
+	}()
+	count := 0
+	for metric := range ch {
+		var m dto.Metric
+		require.NoError(t, metric.Write(&m))
+		labels := make(map[string]string, len(m.GetLabel()))
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels["namespace"] == changefeedID.Namespace &&
+			labels["changefeed"] == changefeedID.ID {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMounterGroupDeleteMetricsOnExit(t *testing.T) {
+	t.Parallel()
+
+	changefeedID := model.DefaultChangeFeedID(t.Name())
+	mg := NewMounterGroup(nil, 2, nil, time.UTC, changefeedID,
+		config.GetDefaultReplicaConfig().Integrity, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- mg.Run(ctx)
+	}()
+
+	// The mounters of the group create the mount duration of the changefeed,
+	// and the mounted rows counters of the three row types.
+	require.Eventually(t, func() bool {
+		return countChangefeedMetrics(t, mountDuration, changefeedID) == 1 &&
+			countChangefeedMetrics(t, mountedRowsCounter, changefeedID) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.Equal(t, context.Canceled, errors.Cause(<-errCh))
+	require.Equal(t, 0, countChangefeedMetrics(t, mountDuration, changefeedID))
+	require.Equal(t, 0, countChangefeedMetrics(t, mountedRowsCounter, changefeedID))
+	require.Equal(t, 0, countChangefeedMetrics(t, mounterGroupInputChanSizeGauge, changefeedID))
+}
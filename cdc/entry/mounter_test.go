@@ -29,16 +29,21 @@ import (
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/executor"
 	tidbkv "github.com/pingcap/tidb/kv"
+	timeta "github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/mock"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/kv"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
@@ -49,6 +54,8 @@ import (
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/pingcap/tiflow/pkg/sqlmodel"
 	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -316,7 +323,7 @@ func testMounterDisableOldValue(t *testing.T, tc struct {
 	filter, err := filter.NewFilter(config, "")
 	require.Nil(t, err)
 	mounter := NewMounter(scheamStorage,
-		model.DefaultChangeFeedID("c1"), time.UTC, filter, config.Integrity).(*mounter)
+		model.DefaultChangeFeedID("c1"), time.UTC, filter, config.Integrity, false).(*mounter)
 	mounter.tz = time.Local
 	ctx := context.Background()
 
@@ -479,6 +486,100 @@ func getLastKeyValueInStore(t *testing.T, store tidbkv.Storage, tableID int64) (
 	return key, value
 }
 
+// mounterTester builds a schema storage and a mounter on top of a schema test
+// helper, so that a test can execute DDLs and mount the KVs written by DMLs.
+type mounterTester struct {
+	t             *testing.T
+	helper        *SchemaTestHelper
+	cfg           *config.ReplicaConfig
+	tz            *time.Location
+	schemaStorage SchemaStorage
+	mounter       *mounter
+}
+
+func newMounterTester(t *testing.T, cfg *config.ReplicaConfig, tz *time.Location) *mounterTester {
+	helper := NewSchemaTestHelper(t)
+	t.Cleanup(helper.Close)
+	helper.Tk().MustExec("use test")
+
+	ver, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.NoError(t, err)
+	m := &mounterTester{t: t, helper: helper, cfg: cfg, tz: tz}
+	m.restart(helper.GetCurrentMeta(), ver.Ver)
+	return m
+}
+
+// restart rebuilds the schema storage and the mounter from the given meta,
+// like a changefeed restarted from startTs.
+func (m *mounterTester) restart(meta *timeta.Meta, startTs uint64) {
+	filter, err := filter.NewFilter(m.cfg, "")
+	require.NoError(m.t, err)
+	changefeed := model.DefaultChangeFeedID(m.t.Name())
+	m.schemaStorage, err = NewSchemaStorage(meta,
+		startTs, m.cfg.ForceReplicate, changefeed, util.RoleTester, filter)
+	require.NoError(m.t, err)
+	m.mounter = NewMounter(m.schemaStorage, changefeed, m.tz, filter, m.cfg.Integrity,
+		m.cfg.Mounter.FullRowImage).(*mounter)
+}
+
+func (m *mounterTester) exec(sql string, args ...interface{}) {
+	m.helper.Tk().MustExec(sql, args...)
+}
+
+// execDDL executes the DDLs, applies them to the schema storage and returns
+// the job of the last one.
+func (m *mounterTester) execDDL(ddls ...string) *timodel.Job {
+	var job *timodel.Job
+	for _, ddl := range ddls {
+		job = m.helper.DDL2Job(ddl)
+		require.NoError(m.t, m.schemaStorage.HandleDDLJob(job))
+	}
+	return job
+}
+
+// tableInfo returns the table info of test.name in the last snapshot.
+func (m *mounterTester) tableInfo(name string) *model.TableInfo {
+	tableInfo, ok := m.schemaStorage.GetLastSnapshot().TableByName("test", name)
+	require.True(m.t, ok)
+	return tableInfo
+}
+
+func (m *mounterTester) lastKV(tableID int64) (key, value []byte) {
+	return getLastKeyValueInStore(m.t, m.helper.Storage(), tableID)
+}
+
+// nextCommitTs returns a commit ts at which rows are mounted with the last
+// snapshot of the schema storage.
+func (m *mounterTester) nextCommitTs() uint64 {
+	return m.schemaStorage.GetLastSnapshot().CurrentTs() + 1
+}
+
+func (m *mounterTester) mount(raw *model.RawKVEntry) *model.RowChangedEvent {
+	row, err := m.mounter.unmarshalAndMountRowChanged(context.Background(), raw)
+	require.NoError(m.t, err)
+	return row
+}
+
+func (m *mounterTester) mountPut(key, value []byte, commitTs uint64) *model.RowChangedEvent {
+	return m.mount(&model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     key,
+		Value:   value,
+		StartTs: commitTs - 1,
+		CRTs:    commitTs,
+	})
+}
+
+func (m *mounterTester) mountDelete(key, oldValue []byte, commitTs uint64) *model.RowChangedEvent {
+	return m.mount(&model.RawKVEntry{
+		OpType:   model.OpTypeDelete,
+		Key:      key,
+		OldValue: oldValue,
+		StartTs:  commitTs - 1,
+		CRTs:     commitTs,
+	})
+}
+
 // We use OriginDefaultValue instead of DefaultValue in the ut, pls ref to
 // https://github.com/pingcap/tiflow/issues/4048
 // FIXME: OriginDefaultValue seems always to be string, and test more corner case
@@ -1108,7 +1209,7 @@ func TestE2ERowLevelChecksum(t *testing.T) {
 	ts := schemaStorage.GetLastSnapshot().CurrentTs()
 	schemaStorage.AdvanceResolvedTs(ver.Ver)
 
-	mounter := NewMounter(schemaStorage, changefeed, time.Local, filter, replicaConfig.Integrity).(*mounter)
+	mounter := NewMounter(schemaStorage, changefeed, time.Local, filter, replicaConfig.Integrity, false).(*mounter)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1223,7 +1324,7 @@ func TestDecodeRowEnableChecksum(t *testing.T) {
 	ts := schemaStorage.GetLastSnapshot().CurrentTs()
 	schemaStorage.AdvanceResolvedTs(ver.Ver)
 
-	mounter := NewMounter(schemaStorage, changefeed, time.Local, filter, replicaConfig.Integrity).(*mounter)
+	mounter := NewMounter(schemaStorage, changefeed, time.Local, filter, replicaConfig.Integrity, false).(*mounter)
 
 	ctx := context.Background()
 
@@ -1353,7 +1454,7 @@ func TestDecodeRow(t *testing.T) {
 
 	schemaStorage.AdvanceResolvedTs(ver.Ver)
 
-	mounter := NewMounter(schemaStorage, changefeed, time.Local, filter, cfg.Integrity).(*mounter)
+	mounter := NewMounter(schemaStorage, changefeed, time.Local, filter, cfg.Integrity, false).(*mounter)
 
 	helper.Tk().MustExec(`insert into student values(1, "dongmen", 20, "male")`)
 	helper.Tk().MustExec(`update student set age = 27 where id = 1`)
@@ -1366,6 +1467,9 @@ func TestDecodeRow(t *testing.T) {
 			row, err := mounter.unmarshalAndMountRowChanged(ctx, rawKV)
 			require.NoError(t, err)
 			require.NotNil(t, row)
+			// every row carries the commit ts of its transaction.
+			require.Equal(t, rawKV.StartTs, row.StartTs)
+			require.Equal(t, rawKV.CRTs, row.CommitTs)
 
 			if row.Columns != nil {
 				require.NotNil(t, mounter.decoder)
@@ -1432,7 +1536,7 @@ func TestDecodeEventIgnoreRow(t *testing.T) {
 
 	ts := schemaStorage.GetLastSnapshot().CurrentTs()
 	schemaStorage.AdvanceResolvedTs(ver.Ver)
-	mounter := NewMounter(schemaStorage, cfID, time.Local, f, cfg.Integrity).(*mounter)
+	mounter := NewMounter(schemaStorage, cfID, time.Local, f, cfg.Integrity, false).(*mounter)
 
 	type testCase struct {
 		schema  string
@@ -1720,3 +1824,738 @@ func TestFormatColVal(t *testing.T) {
 	require.Equal(t, float32(0), value)
 	require.NotZero(t, warn)
 }
+
+// TestDecodeColumnValues tests the values of the columns of a mounted row,
+// which are ordered as declared in the table info at the commit ts of the row.
+func TestDecodeColumnValues(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	testCases := []struct {
+		name     string
+		ddls     []string
+		dmls     []string
+		expected []interface{}
+		// check checks the columns besides their values, it can be nil.
+		check func(cols []*model.Column)
+	}{
+		{
+			name: "unsigned",
+			ddls: []string{"create table t(id int primary key, a int unsigned, b bigint unsigned)"},
+			dmls: []string{"insert into t values (1, 4294967295, 18446744073709551615)"},
+			expected: []interface{}{
+				int64(1), uint64(4294967295), uint64(math.MaxUint64),
+			},
+			check: func(cols []*model.Column) {
+				require.False(t, cols[0].Flag.IsUnsigned())
+				require.True(t, cols[1].Flag.IsUnsigned())
+				require.True(t, cols[2].Flag.IsUnsigned())
+			},
+		},
+		{
+			name: "binary",
+			ddls: []string{"create table t(id int primary key, a varbinary(16), b blob, c binary(3))"},
+			dmls: []string{"insert into t values (1, x'00FF80', x'00FF80', x'00FF80')"},
+			expected: []interface{}{
+				int64(1), []byte{0x00, 0xFF, 0x80}, []byte{0x00, 0xFF, 0x80}, []byte{0x00, 0xFF, 0x80},
+			},
+			check: func(cols []*model.Column) {
+				for _, col := range cols[1:] {
+					require.True(t, col.Flag.IsBinary(), col.Name)
+				}
+			},
+		},
+		{
+			// CHAR values are decoded without their trailing spaces, and
+			// BINARY values keep the zero bytes they are padded with.
+			name:     "char and binary padding",
+			ddls:     []string{"create table t(id int primary key, a char(10), b binary(5))"},
+			dmls:     []string{"insert into t values (1, 'ab  ', 'ab')"},
+			expected: []interface{}{int64(1), []byte("ab"), []byte("ab\x00\x00\x00")},
+		},
+		{
+			// TiDB stores strings in utf8 whatever the charset of the column is.
+			name: "non-utf8 charset",
+			ddls: []string{"create table t(id int primary key, a text character set gbk, " +
+				"b varchar(10) character set gbk, c varchar(10) character set latin1)"},
+			dmls:     []string{"insert into t values (1, '中文', '测试', 'café')"},
+			expected: []interface{}{int64(1), []byte("中文"), []byte("测试"), []byte("café")},
+			check: func(cols []*model.Column) {
+				require.Equal(t, "gbk", cols[1].Charset)
+			},
+		},
+		{
+			name:     "null",
+			ddls:     []string{"create table t(id int primary key, a int, b int, c varchar(10), d varchar(10))"},
+			dmls:     []string{"insert into t values (1, NULL, 0, NULL, '')"},
+			expected: []interface{}{int64(1), nil, int64(0), nil, []byte{}},
+		},
+		{
+			name: "time",
+			ddls: []string{"create table t(id int primary key, a time(3), b time, c time(3), d time)"},
+			dmls: []string{"insert into t values (1, '-01:02:03.456', '838:59:59', '838:59:59', '-838:59:59')"},
+			expected: []interface{}{
+				int64(1), "-01:02:03.456", "838:59:59", "838:59:59.000", "-838:59:59",
+			},
+		},
+		{
+			name: "fractional seconds",
+			ddls: []string{"create table t(id int primary key, " +
+				"a datetime, b datetime(3), c datetime(6), d timestamp(0) null, e timestamp(6) null)"},
+			dmls: []string{
+				"set @@time_zone = '+00:00'",
+				"insert into t values (1, '2023-01-01 00:00:00.123456', '2023-01-01 00:00:00.123456', " +
+					"'2023-01-01 00:00:00.123456', '2023-01-01 00:00:00.123456', '2023-01-01 00:00:00.123456')",
+			},
+			expected: []interface{}{
+				int64(1), "2023-01-01 00:00:00", "2023-01-01 00:00:00.123", "2023-01-01 00:00:00.123456",
+				"2023-01-01 00:00:00", "2023-01-01 00:00:00.123456",
+			},
+		},
+		{
+			name: "year and zero date",
+			ddls: []string{"create table t(id int primary key, a year, b date, c datetime, d timestamp null)"},
+			dmls: []string{
+				"set @@sql_mode = ''",
+				"insert into t values (1, 2024, '0000-00-00', '0000-00-00 00:00:00', '0000-00-00 00:00:00')",
+			},
+			expected: []interface{}{
+				int64(1), int64(2024), "0000-00-00", "0000-00-00 00:00:00", "0000-00-00 00:00:00",
+			},
+		},
+		{
+			name: "column order",
+			ddls: []string{
+				"create table t(id int primary key, a int, b int)",
+				"alter table t add column c int first",
+				"alter table t add column d int after id",
+			},
+			dmls:     []string{"insert into t(id, a, b, c, d) values (1, 2, 3, 4, 5)"},
+			expected: []interface{}{int64(4), int64(1), int64(5), int64(2), int64(3)},
+			check: func(cols []*model.Column) {
+				names := make([]string, 0, len(cols))
+				for _, col := range cols {
+					names = append(names, col.Name)
+				}
+				require.Equal(t, []string{"c", "id", "d", "a", "b"}, names)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		m.execDDL(tc.ddls...)
+		for _, dml := range tc.dmls {
+			m.exec(dml)
+		}
+		key, value := m.lastKV(m.tableInfo("t").ID)
+		row := m.mountPut(key, value, m.nextCommitTs())
+		require.NotNil(t, row, tc.name)
+		values := make([]interface{}, 0, len(row.Columns))
+		for _, col := range row.Columns {
+			values = append(values, col.Value)
+		}
+		require.Equal(t, tc.expected, values, tc.name)
+		if tc.check != nil {
+			tc.check(row.Columns)
+		}
+		m.execDDL("drop table t")
+		m.exec("set @@sql_mode = default, @@time_zone = default")
+	}
+}
+
+// TestDecodeHandleColumns tests the handle columns of a row are decoded for
+// both inserts and deletes, including when the old value of a delete does not
+// carry them and they must be decoded from the record key.
+func TestDecodeHandleColumns(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	// the value crosses a memcomparable group and holds its padding bytes.
+	paddedString := "1234567\x00\x00\x00\x00\x00\x00\x00\x00abc"
+	testCases := []struct {
+		name string
+		ddl  string
+		dml  string
+		args []interface{}
+		// expected holds the values of the columns, the first handleColumns
+		// of them are the handle columns.
+		expected      []interface{}
+		handleColumns int
+		// omitHandleInOldValue also deletes the row with an old value which
+		// only carries the non-handle columns.
+		omitHandleInOldValue bool
+	}{
+		{
+			name:                 "composite clustered primary key",
+			ddl:                  "create table t(a int, b int, c int, primary key(a, b) clustered)",
+			dml:                  "insert into t values (1, 2, 10)",
+			expected:             []interface{}{int64(1), int64(2), int64(10)},
+			handleColumns:        2,
+			omitHandleInOldValue: true,
+		},
+		{
+			name:                 "bigint unsigned handle",
+			ddl:                  "create table t(id bigint unsigned primary key, a int)",
+			dml:                  "insert into t values (18446744073709551614, 1)",
+			expected:             []interface{}{uint64(18446744073709551614), int64(1)},
+			handleColumns:        1,
+			omitHandleInOldValue: true,
+		},
+		{
+			name:          "int and varchar clustered primary key",
+			ddl:           "create table t(a int, b varchar(32), c int, primary key(a, b) clustered)",
+			dml:           "insert into t values (-1, ?, 10)",
+			args:          []interface{}{paddedString},
+			expected:      []interface{}{int64(-1), []byte(paddedString), int64(10)},
+			handleColumns: 2,
+		},
+		{
+			// the record key only carries the collation sort key of the
+			// primary key, the original string is recovered from the value.
+			name: "collated clustered primary key",
+			ddl: "create table t(id varchar(10) collate utf8mb4_general_ci " +
+				"primary key clustered, a int)",
+			dml:           "insert into t values ('AbC', 1)",
+			expected:      []interface{}{[]byte("AbC"), int64(1)},
+			handleColumns: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		m.execDDL(tc.ddl)
+		tableInfo := m.tableInfo("t")
+		m.exec(tc.dml, tc.args...)
+		key, value := m.lastKV(tableInfo.ID)
+		oldValues := [][]byte{value}
+		if tc.omitHandleInOldValue {
+			var (
+				colIDs []int64
+				datums []types.Datum
+			)
+			for i, col := range tableInfo.Columns[tc.handleColumns:] {
+				colIDs = append(colIDs, col.ID)
+				datums = append(datums, types.NewDatum(tc.expected[tc.handleColumns+i]))
+			}
+			oldValue, err := m.mounter.encoder.Encode(m.mounter.sctx, colIDs, datums, nil)
+			require.NoError(t, err, tc.name)
+			oldValues = append(oldValues, oldValue)
+		}
+
+		checkColumns := func(cols []*model.Column) {
+			require.Len(t, cols, len(tc.expected), tc.name)
+			for i, col := range cols {
+				require.Equal(t, tc.expected[i], col.Value, tc.name)
+				require.Equal(t, i < tc.handleColumns, col.Flag.IsHandleKey(), tc.name)
+			}
+		}
+		commitTs := m.nextCommitTs()
+		row := m.mountPut(key, value, commitTs)
+		require.NotNil(t, row, tc.name)
+		checkColumns(row.Columns)
+		for _, oldValue := range oldValues {
+			row = m.mountDelete(key, oldValue, commitTs)
+			require.NotNil(t, row, tc.name)
+			require.True(t, row.IsDelete(), tc.name)
+			checkColumns(row.PreColumns)
+		}
+		m.execDDL("drop table t")
+	}
+}
+
+// TestDecodeSkipIndexKVs tests the index KVs, such as the ones backfilled by
+// ADD INDEX or written for each element of a multi-valued index, are skipped
+// by DecodeEvent, so only the record KVs are mounted as rows.
+func TestDecodeSkipIndexKVs(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	testCases := []struct {
+		name string
+		ddls []string
+		dmls []string
+		// ddlsAfterDMLs are executed after the DMLs, to backfill indexes.
+		ddlsAfterDMLs []string
+		indexKVCount  int
+		expectedIDs   []int64
+	}{
+		{
+			name:          "add index",
+			ddls:          []string{"create table t(id int primary key, a int)"},
+			dmls:          []string{"insert into t values (1, 10), (2, 20), (3, 30)"},
+			ddlsAfterDMLs: []string{"alter table t add index idx_a(a)"},
+			indexKVCount:  3,
+			expectedIDs:   []int64{1, 2, 3},
+		},
+		{
+			name: "multi-valued index",
+			ddls: []string{"create table t(id int primary key, j json, " +
+				"index idx((cast(j->'$' as signed array))))"},
+			dmls:         []string{"insert into t values (1, '[1, 2, 3]')"},
+			indexKVCount: 3,
+			expectedIDs:  []int64{1},
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		m.execDDL(tc.ddls...)
+		for _, dml := range tc.dmls {
+			m.exec(dml)
+		}
+		m.execDDL(tc.ddlsAfterDMLs...)
+		tableInfo := m.tableInfo("t")
+		require.Len(t, tableInfo.Indices, 1, tc.name)
+
+		// The puller only subscribes to the record range of a table, see
+		// spanz.GetTableRange, so walk the whole table prefix here to check
+		// the mounter skips index KVs even if it receives them.
+		txn, err := m.helper.Storage().Begin()
+		require.NoError(t, err)
+		kvIter, err := txn.Iter(tablecodec.GenTablePrefix(tableInfo.ID),
+			tablecodec.GenTablePrefix(tableInfo.ID+1))
+		require.NoError(t, err)
+
+		commitTs := m.nextCommitTs()
+		indexKVCount := 0
+		var ids []int64
+		for kvIter.Valid() {
+			if !tablecodec.IsRecordKey(kvIter.Key()) {
+				indexKVCount++
+			}
+			event := model.NewPolymorphicEvent(&model.RawKVEntry{
+				OpType:  model.OpTypePut,
+				Key:     kvIter.Key(),
+				Value:   kvIter.Value(),
+				StartTs: commitTs - 1,
+				CRTs:    commitTs,
+			})
+			require.NoError(t, m.mounter.DecodeEvent(ctx, event), tc.name)
+			if event.Row != nil {
+				require.True(t, event.Row.IsInsert(), tc.name)
+				ids = append(ids, event.Row.Columns[0].Value.(int64))
+			}
+			require.NoError(t, kvIter.Next())
+		}
+		kvIter.Close()
+		require.NoError(t, txn.Rollback())
+
+		require.Equal(t, tc.indexKVCount, indexKVCount, tc.name)
+		require.Equal(t, tc.expectedIDs, ids, tc.name)
+		m.execDDL("drop table t")
+	}
+}
+
+// TestDecodeEventMetrics tests mounted rows are counted by their type, and the
+// mount duration is observed for every row KV, including the failed ones.
+func TestDecodeEventMetrics(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	m.execDDL("create table t(id int primary key, a int)")
+	m.exec("insert into t values (1, 1)")
+	tableInfo := m.tableInfo("t")
+	key, value := m.lastKV(tableInfo.ID)
+
+	ctx := context.Background()
+	commitTs := m.nextCommitTs()
+	for _, raw := range []*model.RawKVEntry{
+		{OpType: model.OpTypePut, Key: key, Value: value},
+		{OpType: model.OpTypePut, Key: key, Value: value, OldValue: value},
+		{OpType: model.OpTypePut, Key: key, Value: value, OldValue: value},
+		{OpType: model.OpTypeDelete, Key: key, OldValue: value},
+	} {
+		raw.StartTs, raw.CRTs = commitTs-1, commitTs
+		require.NoError(t, m.mounter.DecodeEvent(ctx, model.NewPolymorphicEvent(raw)))
+	}
+	// a row of a table which is not in the snapshot fails to mount.
+	err := m.mounter.DecodeEvent(ctx, model.NewPolymorphicEvent(&model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     tablecodec.EncodeRowKeyWithHandle(tableInfo.ID+1000, tidbkv.IntHandle(1)),
+		Value:   value,
+		StartTs: commitTs - 1,
+		CRTs:    commitTs,
+	}))
+	require.True(t, cerror.ErrSnapshotTableNotFound.Equal(err))
+
+	var metric dto.Metric
+	for _, c := range []struct {
+		counter  prometheus.Counter
+		expected float64
+	}{
+		{counter: m.mounter.metricInsertRows, expected: 1},
+		{counter: m.mounter.metricUpdateRows, expected: 2},
+		{counter: m.mounter.metricDeleteRows, expected: 1},
+	} {
+		require.NoError(t, c.counter.Write(&metric))
+		require.Equal(t, c.expected, metric.GetCounter().GetValue())
+	}
+	require.NoError(t, m.mounter.metricMountDuration.(prometheus.Histogram).Write(&metric))
+	require.Equal(t, uint64(5), metric.GetHistogram().GetSampleCount())
+}
+
+// TestDecodeEventCanceled tests DecodeEvent returns promptly once the context
+// is canceled, even if it is still waiting for the schema storage to resolve.
+func TestDecodeEventCanceled(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	m.execDDL("create table t(id int primary key, a int)")
+	m.exec("insert into t values (1, 1)")
+	key, value := m.lastKV(m.tableInfo("t").ID)
+
+	// The resolved ts of the schema storage is never advanced past the DDL,
+	// so decoding the event blocks until the context is canceled.
+	ts := m.schemaStorage.GetLastSnapshot().CurrentTs()
+	pEvent := model.NewPolymorphicEvent(&model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     key,
+		Value:   value,
+		StartTs: ts + 1,
+		CRTs:    ts + 2,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := m.mounter.DecodeEvent(ctx, pEvent)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, pEvent.Row)
+}
+
+// TestDecodeRowBetweenAddAndDropColumn tests a row is decoded with the table
+// info of the snapshot at its commit ts, so a row committed after a column is
+// added and before it is dropped still carries that column.
+func TestDecodeRowBetweenAddAndDropColumn(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	addTs := m.execDDL(
+		"create table t(id int primary key, a int)",
+		"alter table t add column b int",
+	).BinlogInfo.FinishedTS
+	m.exec("insert into t values (1, 2, 3)")
+	key, value := m.lastKV(m.tableInfo("t").ID)
+	dropTs := m.execDDL("alter table t drop column b").BinlogInfo.FinishedTS
+
+	// committed between the add column and the drop column.
+	row := m.mountPut(key, value, addTs+1)
+	require.NotNil(t, row)
+	require.Len(t, row.Columns, 3)
+	require.Equal(t, "b", row.Columns[2].Name)
+	require.Equal(t, int64(3), row.Columns[2].Value)
+
+	// committed after the drop column.
+	row = m.mountPut(key, value, dropTs+1)
+	require.NotNil(t, row)
+	require.Len(t, row.Columns, 2)
+	require.Equal(t, "a", row.Columns[1].Name)
+}
+
+// TestDecodeRowAfterAlterColumn tests rows written before and after an ALTER
+// TABLE which changes a column, and does not rewrite the existing rows, are
+// both decoded with the new table info.
+func TestDecodeRowAfterAlterColumn(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	testCases := []struct {
+		name           string
+		ddl            string
+		insertBefore   string
+		alters         []string
+		insertAfter    string
+		expectedNames  []string
+		expectedBefore []interface{}
+		expectedAfter  []interface{}
+	}{
+		{
+			name:         "modify column",
+			ddl:          "create table t(id int primary key, a int, b varchar(10))",
+			insertBefore: "insert into t values (1, 1, 'x')",
+			alters: []string{
+				"alter table t modify column a bigint",
+				"alter table t modify column b varchar(20)",
+			},
+			insertAfter:    "insert into t values (2, 1099511627776, '0123456789abcdef')",
+			expectedNames:  []string{"id", "a", "b"},
+			expectedBefore: []interface{}{int64(1), int64(1), []byte("x")},
+			expectedAfter:  []interface{}{int64(2), int64(1099511627776), []byte("0123456789abcdef")},
+		},
+		{
+			name:           "change column name",
+			ddl:            "create table t(id int primary key, a int)",
+			insertBefore:   "insert into t values (1, 1)",
+			alters:         []string{"alter table t change column a b int"},
+			insertAfter:    "insert into t values (2, 2)",
+			expectedNames:  []string{"id", "b"},
+			expectedBefore: []interface{}{int64(1), int64(1)},
+			expectedAfter:  []interface{}{int64(2), int64(2)},
+		},
+		{
+			// the origin default value is kept as it is stored in the table info.
+			name:           "add not null column with default value",
+			ddl:            "create table t(id int primary key, a int)",
+			insertBefore:   "insert into t values (1, 1)",
+			alters:         []string{"alter table t add column b int not null default 5"},
+			insertAfter:    "insert into t values (2, 2, 6)",
+			expectedNames:  []string{"id", "a", "b"},
+			expectedBefore: []interface{}{int64(1), int64(1), "5"},
+			expectedAfter:  []interface{}{int64(2), int64(2), int64(6)},
+		},
+	}
+
+	for _, tc := range testCases {
+		m.execDDL(tc.ddl)
+		tableInfo := m.tableInfo("t")
+		m.exec(tc.insertBefore)
+		keyBefore, valueBefore := m.lastKV(tableInfo.ID)
+		m.execDDL(tc.alters...)
+		// The DDLs do not rewrite the existing row, so it is decoded from the
+		// value written with the old table info.
+		key, value := m.lastKV(tableInfo.ID)
+		require.Equal(t, keyBefore, key, tc.name)
+		require.Equal(t, valueBefore, value, tc.name)
+		m.exec(tc.insertAfter)
+		keyAfter, valueAfter := m.lastKV(tableInfo.ID)
+
+		commitTs := m.nextCommitTs()
+		for i, kvPair := range [][2][]byte{{keyBefore, valueBefore}, {keyAfter, valueAfter}} {
+			row := m.mountPut(kvPair[0], kvPair[1], commitTs)
+			require.NotNil(t, row, tc.name)
+			names := make([]string, 0, len(row.Columns))
+			values := make([]interface{}, 0, len(row.Columns))
+			for _, col := range row.Columns {
+				names = append(names, col.Name)
+				values = append(values, col.Value)
+			}
+			require.Equal(t, tc.expectedNames, names, tc.name)
+			if i == 0 {
+				require.Equal(t, tc.expectedBefore, values, tc.name)
+			} else {
+				require.Equal(t, tc.expectedAfter, values, tc.name)
+			}
+		}
+		m.execDDL("drop table t")
+	}
+}
+
+// TestDecodeRowsAfterPartitionDDL tests rows of a partition table are decoded
+// with their partition, also after adding, truncating and dropping partitions,
+// and rows of a removed partition committed after the DDL are skipped.
+func TestDecodeRowsAfterPartitionDDL(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	m.execDDL("create table t(id int primary key, a int) partition by range(id) " +
+		"(partition p0 values less than (10), partition p1 values less than (20))")
+	partitionIDs := func() []int64 {
+		var ids []int64
+		for _, partition := range m.tableInfo("t").GetPartitionInfo().Definitions {
+			ids = append(ids, partition.ID)
+		}
+		return ids
+	}
+
+	m.exec("insert into t values (1, 1), (11, 11)")
+	oldIDs := partitionIDs()
+	p0Key, p0Value := m.lastKV(oldIDs[0])
+	p1Key, p1Value := m.lastKV(oldIDs[1])
+
+	// rows of a partition are decoded with the logical table name and the
+	// physical partition ID.
+	commitTs := m.nextCommitTs()
+	for i, kvPair := range [][2][]byte{{p0Key, p0Value}, {p1Key, p1Value}} {
+		row := m.mountPut(kvPair[0], kvPair[1], commitTs)
+		require.NotNil(t, row)
+		require.Equal(t, "test", row.Table.Schema)
+		require.Equal(t, "t", row.Table.Table)
+		require.Equal(t, oldIDs[i], row.Table.TableID)
+		require.True(t, row.Table.IsPartition)
+		require.Equal(t, int64(i*10+1), row.Columns[0].Value)
+	}
+
+	// add partition.
+	addTs := m.execDDL("alter table t add partition (partition p2 values less than (30))").
+		BinlogInfo.FinishedTS
+	ids := partitionIDs()
+	require.Len(t, ids, 3)
+	m.exec("insert into t values (21, 21)")
+	key, value := m.lastKV(ids[2])
+	row := m.mountPut(key, value, addTs+1)
+	require.NotNil(t, row)
+	require.Equal(t, "t", row.Table.Table)
+	require.Equal(t, ids[2], row.Table.TableID)
+	require.Equal(t, int64(21), row.Columns[0].Value)
+
+	// truncate partition.
+	truncateTs := m.execDDL("alter table t truncate partition p0").BinlogInfo.FinishedTS
+	ids = partitionIDs()
+	require.NotEqual(t, oldIDs[0], ids[0])
+	// committed before the truncate partition.
+	row = m.mountPut(p0Key, p0Value, truncateTs)
+	require.NotNil(t, row)
+	require.Equal(t, oldIDs[0], row.Table.TableID)
+	// committed after the truncate partition.
+	require.Nil(t, m.mountPut(p0Key, p0Value, truncateTs+1))
+	m.exec("insert into t values (2, 2)")
+	key, value = m.lastKV(ids[0])
+	row = m.mountPut(key, value, truncateTs+1)
+	require.NotNil(t, row)
+	require.Equal(t, ids[0], row.Table.TableID)
+
+	// drop partition.
+	dropTs := m.execDDL("alter table t drop partition p1").BinlogInfo.FinishedTS
+	require.Len(t, partitionIDs(), 2)
+	// committed before the drop partition.
+	row = m.mountPut(p1Key, p1Value, dropTs)
+	require.NotNil(t, row)
+	require.Equal(t, oldIDs[1], row.Table.TableID)
+	// committed after the drop partition.
+	require.Nil(t, m.mountPut(p1Key, p1Value, dropTs+1))
+}
+
+// TestDecodeUpdateFullImage tests an update is decoded with the complete
+// before and after images. A column missing from the old value is filled with
+// its original default value, or marked unknown in the full row image mode.
+func TestDecodeUpdateFullImage(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	m.execDDL("create table t(id int primary key, a int, b varchar(10))")
+	m.exec("insert into t values (1, 1, 'x')")
+	tableInfo := m.tableInfo("t")
+	_, oldValue := m.lastKV(tableInfo.ID)
+
+	m.execDDL("alter table t add column c int default 5")
+	m.exec("update t set a = 2 where id = 1")
+	key, value := m.lastKV(tableInfo.ID)
+
+	for _, fullRowImage := range []bool{false, true} {
+		m.mounter.fullRowImage = fullRowImage
+		commitTs := m.nextCommitTs()
+		row := m.mount(&model.RawKVEntry{
+			OpType:   model.OpTypePut,
+			Key:      key,
+			Value:    value,
+			OldValue: oldValue,
+			StartTs:  commitTs - 1,
+			CRTs:     commitTs,
+		})
+		require.NotNil(t, row)
+		require.True(t, row.IsUpdate())
+
+		require.Len(t, row.PreColumns, 4)
+		require.Len(t, row.Columns, 4)
+		for i, name := range []string{"id", "a", "b", "c"} {
+			require.Equal(t, name, row.PreColumns[i].Name)
+			require.Equal(t, name, row.Columns[i].Name)
+			require.False(t, row.Columns[i].Flag.IsUnknownValue())
+		}
+		require.Equal(t, int64(1), row.PreColumns[1].Value)
+		require.Equal(t, int64(2), row.Columns[1].Value)
+		require.Equal(t, []byte("x"), row.PreColumns[2].Value)
+		require.Equal(t, []byte("x"), row.Columns[2].Value)
+		require.Equal(t, int64(5), row.Columns[3].Value)
+		for i := 0; i < 3; i++ {
+			require.False(t, row.PreColumns[i].Flag.IsUnknownValue())
+		}
+		// the old value is written before column c is added.
+		if fullRowImage {
+			require.True(t, row.PreColumns[3].Flag.IsUnknownValue())
+			require.Nil(t, row.PreColumns[3].Value)
+		} else {
+			require.False(t, row.PreColumns[3].Flag.IsUnknownValue())
+			require.EqualValues(t, "5", row.PreColumns[3].Value)
+		}
+	}
+}
+
+// TestDecodeRowFromCheckpointAtDDL tests a changefeed restarted from a
+// checkpoint ts equal to the finished ts of a DDL builds its schema with that
+// DDL applied, ignores the DDL if it is received again, and decodes later
+// rows with the new schema.
+func TestDecodeRowFromCheckpointAtDDL(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	m.exec("create table t(id int primary key, a int)")
+	job := m.helper.DDL2Job("alter table t add column b int")
+	checkpointTs := job.BinlogInfo.FinishedTS
+
+	meta, err := kv.GetSnapshotMeta(m.helper.Storage(), checkpointTs)
+	require.NoError(t, err)
+	m.restart(meta, checkpointTs)
+	require.Equal(t, checkpointTs, m.schemaStorage.ResolvedTs())
+	require.Len(t, m.tableInfo("t").Columns, 3)
+
+	// The DDL at the checkpoint ts has been replicated, so it is ignored.
+	require.NoError(t, m.schemaStorage.HandleDDLJob(job))
+	tableInfo := m.tableInfo("t")
+	require.Len(t, tableInfo.Columns, 3)
+
+	m.exec("insert into t values (1, 2, 3)")
+	key, value := m.lastKV(tableInfo.ID)
+	row := m.mountPut(key, value, checkpointTs+1)
+	require.NotNil(t, row)
+	require.Greater(t, row.CommitTs, checkpointTs)
+	require.Len(t, row.Columns, 3)
+	require.Equal(t, "b", row.Columns[2].Name)
+	require.Equal(t, int64(3), row.Columns[2].Value)
+}
+
+// TestDecodeRowIDOfTableWithoutPK tests rows of a table without a primary key
+// carry the implicit _tidb_rowid in RowID, which stays the same from the
+// insert to the delete of a row, and the hidden column is not exposed.
+func TestDecodeRowIDOfTableWithoutPK(t *testing.T) {
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.ForceReplicate = true
+	m := newMounterTester(t, cfg, time.UTC)
+	m.execDDL("create table t(a int, b int)")
+	m.exec("insert into t values (1, 1), (2, 2)")
+	key, value := m.lastKV(m.tableInfo("t").ID)
+
+	commitTs := m.nextCommitTs()
+	insert := m.mountPut(key, value, commitTs)
+	require.NotNil(t, insert)
+	require.NotZero(t, insert.RowID)
+	require.Len(t, insert.Columns, 2)
+	for _, col := range insert.Columns {
+		require.NotEqual(t, timodel.ExtraHandleName.O, col.Name)
+	}
+
+	deleteRow := m.mountDelete(key, value, commitTs)
+	require.NotNil(t, deleteRow)
+	require.True(t, deleteRow.IsDelete())
+	require.Equal(t, insert.RowID, deleteRow.RowID)
+	require.Equal(t, insert.Columns[0].Value, deleteRow.PreColumns[0].Value)
+}
+
+// TestDecodeRowFormatV1AndV2 tests rows written in both the v1 and the v2 row
+// format are decoded, and a column added after the row is written is decoded
+// as its default value in both formats.
+func TestDecodeRowFormatV1AndV2(t *testing.T) {
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), time.UTC)
+	m.execDDL("create table t(id int primary key, a varchar(10))")
+	tableInfo := m.tableInfo("t")
+
+	m.exec("insert into t values (1, 'v')")
+	key, valueV2 := m.lastKV(tableInfo.ID)
+	require.True(t, rowcodec.IsNewFormat(valueV2))
+	valueV1, err := tablecodec.EncodeOldRow(&stmtctx.StatementContext{TimeZone: time.UTC},
+		[]types.Datum{types.NewStringDatum("v")}, []int64{tableInfo.Columns[1].ID}, nil, nil)
+	require.NoError(t, err)
+	require.False(t, rowcodec.IsNewFormat(valueV1))
+
+	m.execDDL("alter table t add column b int default 7")
+	commitTs := m.nextCommitTs()
+	for _, value := range [][]byte{valueV1, valueV2} {
+		row := m.mountPut(key, value, commitTs)
+		require.NotNil(t, row)
+		require.Len(t, row.Columns, 3)
+		require.Equal(t, int64(1), row.Columns[0].Value)
+		require.Equal(t, []byte("v"), row.Columns[1].Value)
+		// the origin default value is kept as it is stored in the table info.
+		require.Equal(t, "7", row.Columns[2].Value)
+	}
+}
+
+// TestDecodeOnUpdateCurrentTimestampColumn tests an UPDATE which does not
+// mention an ON UPDATE CURRENT_TIMESTAMP column is decoded with the
+// auto-updated value, converted to the time zone of the mounter.
+func TestDecodeOnUpdateCurrentTimestampColumn(t *testing.T) {
+	tz := time.FixedZone("UTC+8", 8*60*60)
+	m := newMounterTester(t, config.GetDefaultReplicaConfig(), tz)
+	m.exec("set @@time_zone = '+08:00'")
+	m.execDDL("create table t(id int primary key, a int, " +
+		"b timestamp default current_timestamp on update current_timestamp)")
+
+	m.exec("insert into t values (1, 1, '2020-01-01 00:00:00')")
+	m.exec("update t set a = 2 where id = 1")
+	expected := m.helper.Tk().MustQuery("select b from t where id = 1").Rows()[0][0].(string)
+	require.NotEqual(t, "2020-01-01 00:00:00", expected)
+
+	key, value := m.lastKV(m.tableInfo("t").ID)
+	row := m.mountPut(key, value, m.nextCommitTs())
+	require.NotNil(t, row)
+	require.Len(t, row.Columns, 3)
+	require.EqualValues(t, 2, row.Columns[1].Value)
+	require.Equal(t, expected, row.Columns[2].Value)
+}
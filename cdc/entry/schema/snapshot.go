@@ -364,7 +364,10 @@ func (s *Snapshot) SchemaByTableID(tableID int64) (*timodel.DBInfo, bool) {
 	return s.inner.schemaByID(tableInfo.SchemaID)
 }
 
-// IsTruncateTableID returns true if the table id have been truncated by truncate table DDL.
+// IsTruncateTableID returns true if the table id has been truncated by a truncate
+// table DDL, or is a partition removed by a truncate, drop or reorganize partition
+// DDL. A partition replaced by exchange partition is not included, because its ID
+// becomes the ID of the source table.
 func (s *Snapshot) IsTruncateTableID(id int64) bool {
 	s.rwlock.RLock()
 	defer s.rwlock.RUnlock()
@@ -471,7 +474,7 @@ func (s *Snapshot) DoHandleDDL(job *timodel.Job) error {
 		timodel.ActionAddTablePartition,
 		timodel.ActionDropTablePartition,
 		timodel.ActionReorganizePartition:
-		err := s.inner.updatePartition(getWrapTableInfo(job), job.BinlogInfo.FinishedTS)
+		err := s.inner.updatePartition(getWrapTableInfo(job), true, job.BinlogInfo.FinishedTS)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -777,6 +780,10 @@ func (s *snapshot) doDropTable(tbInfo *model.TableInfo, currentTs uint64) {
 // NOTE: after a table is truncated:
 //   - physicalTableByID(id) will return nil;
 //   - IsTruncateTableID(id) should return true.
+//
+// Partitions removed by truncate, drop or reorganize partition are marked in
+// the same way by updatePartition, while the partition replaced by exchange
+// partition is not.
 func (s *snapshot) truncateTable(id int64, tbInfo *model.TableInfo, currentTs uint64) (err error) {
 	old, ok := s.physicalTableByID(id)
 	if !ok {
@@ -856,8 +863,9 @@ func (s *snapshot) doCreateTable(tbInfo *model.TableInfo, currentTs uint64) {
 	}
 }
 
-// updatePartition updates partition info for `tbInfo`.
-func (s *snapshot) updatePartition(tbInfo *model.TableInfo, currentTs uint64) error {
+// updatePartition updates partition info for `tbInfo`. If `markRemoved` is
+// true, the partitions removed from `tbInfo` are handled like truncated tables.
+func (s *snapshot) updatePartition(tbInfo *model.TableInfo, markRemoved bool, currentTs uint64) error {
 	oldTbInfo, ok := s.physicalTableByID(tbInfo.ID)
 	if !ok {
 		return cerror.ErrSnapshotTableNotFound.GenWithStackByArgs(tbInfo.ID)
@@ -879,8 +887,17 @@ func (s *snapshot) updatePartition(tbInfo *model.TableInfo, currentTs uint64) er
 	if ineligible {
 		s.ineligibleTables.ReplaceOrInsert(newVersionedID(tbInfo.ID, tag))
 	}
+	newIDs := make(map[int64]struct{}, len(newPi.Definitions))
+	for _, partition := range newPi.Definitions {
+		newIDs[partition.ID] = struct{}{}
+	}
 	for _, partition := range oldPi.Definitions {
 		s.partitions.ReplaceOrInsert(newVersionedID(partition.ID, tag))
+		// Partitions removed by dropping or truncating are handled like
+		// truncated tables, so in-flight DMLs of them can be skipped.
+		if _, ok := newIDs[partition.ID]; markRemoved && !ok {
+			s.truncatedTables.ReplaceOrInsert(newVersionedID(partition.ID, tag))
+		}
 	}
 	for _, partition := range newPi.Definitions {
 		vid := newVersionedID(partition.ID, tag)
@@ -978,7 +995,8 @@ func (s *snapshot) exchangePartition(targetTable *model.TableInfo, currentTS uin
 	// ref: https://github.com/pingcap/tidb/issues/43819
 	targetTable.SchemaID = oldTable.SchemaID
 	targetTable.TableName = oldTable.TableName
-	err = s.updatePartition(targetTable, currentTS)
+	// The exchanged partition is not removed but becomes the source table.
+	err = s.updatePartition(targetTable, false, currentTS)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1207,6 +1225,9 @@ func (s *snapshot) drop() {
 			if ineligible {
 				s.ineligibleTables.Delete(vid)
 			}
+		} else {
+			// Maybe the partition is dropped or truncated.
+			s.truncatedTables.Delete(vid)
 		}
 	}
 
@@ -219,19 +219,19 @@ func TestUpdatePartition(t *testing.T) {
 	oldTb = newTbInfo(1, "DB_1", 11)
 	oldTb.Partition = nil
 	require.Nil(t, snap.inner.createTable(oldTb, 110))
-	require.Error(t, snap.inner.updatePartition(newTbInfo(1, "DB_1", 11), 120))
+	require.Error(t, snap.inner.updatePartition(newTbInfo(1, "DB_1", 11), true, 120))
 
 	// updatePartition fails if the new table is not partitioned.
 	require.Nil(t, snap.inner.dropTable(11, 130))
 	require.Nil(t, snap.inner.createTable(newTbInfo(1, "DB_1", 11), 140))
 	newTb = newTbInfo(1, "DB_1", 11)
 	newTb.Partition = nil
-	require.Error(t, snap.inner.updatePartition(newTb, 150))
+	require.Error(t, snap.inner.updatePartition(newTb, true, 150))
 	snap1 = snap.Copy()
 
 	newTb = newTbInfo(1, "DB_1", 11)
 	newTb.Partition.Definitions[0] = timodel.PartitionDefinition{ID: 11 + 65536*2}
-	require.Nil(t, snap.inner.updatePartition(newTb, 160))
+	require.Nil(t, snap.inner.updatePartition(newTb, true, 160))
 	snap2 = snap.Copy()
 
 	info, _ = snap1.PhysicalTableByID(11)
@@ -248,9 +248,27 @@ func TestUpdatePartition(t *testing.T) {
 	_, ok = snap2.PhysicalTableByID(11 + 65536)
 	require.False(t, ok)
 	require.False(t, snap2.IsIneligibleTableID(11+65536))
+	require.False(t, snap1.IsTruncateTableID(11+65536))
+	require.True(t, snap2.IsTruncateTableID(11+65536))
 	_, ok = snap2.PhysicalTableByID(11 + 65536*2)
 	require.True(t, ok)
 	require.True(t, snap2.IsIneligibleTableID(11+65536*2))
+
+	// The exchanged partition becomes the source table, so it is not
+	// handled like a truncated table.
+	require.Nil(t, snap.inner.createTable(newTbInfo(1, "DB_1", 12), 170))
+	newTb = newTbInfo(1, "DB_1", 11)
+	newTb.Partition.Definitions[0] = timodel.PartitionDefinition{ID: 12}
+	require.Nil(t, snap.inner.exchangePartition(newTb, 180))
+	snap3 := snap.Copy()
+
+	require.False(t, snap3.IsTruncateTableID(11+65536*2))
+	info, ok = snap3.PhysicalTableByID(11 + 65536*2)
+	require.True(t, ok)
+	require.Equal(t, "TB_12", info.Name.O)
+	info, ok = snap3.PhysicalTableByID(12)
+	require.True(t, ok)
+	require.Equal(t, int64(11), info.ID)
 }
 
 func TestExchangePartition(t *testing.T) {
@@ -326,6 +344,41 @@ func TestDrop(t *testing.T) {
 	require.Equal(t, 2, snap.inner.ineligibleTables.Len())
 }
 
+func TestHandleUnknownDDL(t *testing.T) {
+	snap := NewEmptySnapshot(false)
+	require.Nil(t, snap.inner.createSchema(newDBInfo(1), 100))
+	require.Nil(t, snap.inner.createTable(newTbInfo(1, "DB_1", 11), 110))
+
+	// A job of an action unknown to the snapshot still refreshes the table
+	// info from the job.
+	tbInfo := newTbInfo(1, "DB_1", 11).TableInfo
+	tbInfo.Columns = []*timodel.ColumnInfo{{ID: 1, Name: timodel.NewCIStr("c1")}}
+	job := &timodel.Job{
+		Type:       timodel.ActionType(255),
+		SchemaID:   1,
+		TableID:    11,
+		SchemaName: "DB_1",
+		BinlogInfo: &timodel.HistoryInfo{FinishedTS: 120, TableInfo: tbInfo},
+	}
+	require.Nil(t, snap.DoHandleDDL(job))
+	info, ok := snap.PhysicalTableByID(11)
+	require.True(t, ok)
+	require.Len(t, info.Columns, 1)
+	require.Equal(t, uint64(120), info.Version)
+
+	// A job without table info is ignored.
+	job = &timodel.Job{
+		Type:       timodel.ActionType(255),
+		SchemaID:   1,
+		TableID:    11,
+		BinlogInfo: &timodel.HistoryInfo{FinishedTS: 130},
+	}
+	require.Nil(t, snap.DoHandleDDL(job))
+	info, ok = snap.PhysicalTableByID(11)
+	require.True(t, ok)
+	require.Equal(t, uint64(120), info.Version)
+}
+
 func newDBInfo(id int64) *timodel.DBInfo {
 	return &timodel.DBInfo{
 		ID: id,
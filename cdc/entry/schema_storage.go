@@ -66,7 +66,10 @@ type schemaStorageImpl struct {
 	role util.Role
 }
 
-// NewSchemaStorage creates a new schema storage
+// NewSchemaStorage creates a new schema storage.
+// If meta is nil, the storage starts with an empty snapshot. forceReplicate
+// is the force-replicate option of the changefeed, it makes tables without a
+// valid index eligible for replication.
 func NewSchemaStorage(
 	meta *timeta.Meta, startTs uint64,
 	forceReplicate bool, id model.ChangeFeedID,
@@ -707,6 +707,42 @@ func TestCreateSnapFromMeta(t *testing.T) {
 	require.Equal(t, dbInfo.Name.O, "test2")
 }
 
+func TestHandleDDLJobsAfterSnapshot(t *testing.T) {
+	helper := NewSchemaTestHelper(t)
+	defer helper.Close()
+
+	oldJob := helper.DDL2Job("create table test.t1(id int primary key)")
+	snapJob := helper.DDL2Job("create table test.t2(id int primary key)")
+
+	meta := helper.GetCurrentMeta()
+	version, err := schema.GetSchemaVersion(meta)
+	require.Nil(t, err)
+	require.Equal(t, snapJob.BinlogInfo.SchemaVersion, version)
+	ver, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	require.Nil(t, err)
+	f, err := filter.NewFilter(config.GetDefaultReplicaConfig(), "")
+	require.Nil(t, err)
+	storage, err := NewSchemaStorage(meta, ver.Ver, false,
+		model.DefaultChangeFeedID("test"), util.RoleTester, f)
+	require.Nil(t, err)
+
+	newJob := helper.DDL2Job("create table test.t3(id int primary key)")
+
+	// Jobs at or below the schema version of the snapshot are already
+	// contained in it, and must be skipped.
+	for _, job := range []*timodel.Job{oldJob, snapJob, newJob} {
+		require.Nil(t, storage.HandleDDLJob(job))
+	}
+	require.Len(t, storage.(*schemaStorageImpl).snaps, 2)
+
+	snap := storage.GetLastSnapshot()
+	require.Equal(t, newJob.BinlogInfo.FinishedTS, snap.CurrentTs())
+	for _, name := range []string{"t1", "t2", "t3"} {
+		_, ok := snap.TableByName("test", name)
+		require.True(t, ok, name)
+	}
+}
+
 func TestExplicitTables(t *testing.T) {
 	store, err := mockstore.NewMockStore()
 	require.Nil(t, err)
@@ -971,6 +1007,9 @@ func TestHandleKey(t *testing.T) {
 	tk.MustExec("create table test.simple_test2 (id bigint, age int NOT NULL, " +
 		"name char NOT NULL, UNIQUE KEY(age, name))")
 	tk.MustExec("create table test.simple_test3 (id bigint, age int)")
+	tk.MustExec("create table test.simple_test4 (id varchar(10) primary key nonclustered, age int)")
+	tk.MustExec("create table test.simple_test5 (id int, name varchar(10), age int, " +
+		"primary key(id, name) clustered)")
 	ver, err := store.CurrentVersion(oracle.GlobalTxnScope)
 	require.Nil(t, err)
 	meta, err := kv.GetSnapshotMeta(store, ver.Ver)
@@ -1008,4 +1047,27 @@ func TestHandleKey(t *testing.T) {
 	tb3, ok := snap.TableByName("test", "simple_test3")
 	require.True(t, ok)
 	require.Equal(t, int64(-2), tb3.HandleIndexID)
+
+	isHandleKey := func(tb *model.TableInfo, offset int) bool {
+		flag := tb.ColumnsFlag[tb.Columns[offset].ID]
+		return flag.IsHandleKey()
+	}
+
+	// non-clustered primary key is not the handle, but it is the handleKey
+	tb4, ok := snap.TableByName("test", "simple_test4")
+	require.True(t, ok)
+	require.False(t, tb4.PKIsHandle)
+	require.False(t, tb4.IsCommonHandle)
+	require.Equal(t, tb4.Indices[0].ID, tb4.HandleIndexID)
+	require.True(t, isHandleKey(tb4, 0))
+	require.False(t, isHandleKey(tb4, 1))
+
+	// clustered composite primary key is the handle
+	tb5, ok := snap.TableByName("test", "simple_test5")
+	require.True(t, ok)
+	require.True(t, tb5.IsCommonHandle)
+	require.Equal(t, int64(-1), tb5.HandleIndexID)
+	require.True(t, isHandleKey(tb5, 0))
+	require.True(t, isHandleKey(tb5, 1))
+	require.False(t, isHandleKey(tb5, 2))
 }
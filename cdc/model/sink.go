@@ -81,6 +81,9 @@ const (
 	NullableFlag
 	// UnsignedFlag means the column stores an unsigned integer
 	UnsignedFlag
+	// UnknownValueFlag means the value of the column is unknown, because the
+	// column is not present in the old value of the row
+	UnknownValueFlag
 )
 
 // SetIsBinary sets BinaryFlag
@@ -203,6 +206,21 @@ func (b *ColumnFlagType) UnsetIsUnsigned() {
 	(*util.Flag)(b).Remove(util.Flag(UnsignedFlag))
 }
 
+// IsUnknownValue shows whether UnknownValueFlag is set
+func (b *ColumnFlagType) IsUnknownValue() bool {
+	return (*util.Flag)(b).HasAll(util.Flag(UnknownValueFlag))
+}
+
+// SetIsUnknownValue sets UnknownValueFlag
+func (b *ColumnFlagType) SetIsUnknownValue() {
+	(*util.Flag)(b).Add(util.Flag(UnknownValueFlag))
+}
+
+// UnsetIsUnknownValue unsets UnknownValueFlag
+func (b *ColumnFlagType) UnsetIsUnknownValue() {
+	(*util.Flag)(b).Remove(util.Flag(UnknownValueFlag))
+}
+
 // TableName represents name of a table, includes table name and schema name.
 type TableName struct {
 	Schema      string `toml:"db-name" json:"db-name" msg:"db-name"`
@@ -767,6 +785,16 @@ func (t *SingleTableTxn) GetCommitTs() uint64 {
 	return t.CommitTs
 }
 
+// ApproximateDataSize returns the sum of the approximate data size of rows
+// in the transaction.
+func (t *SingleTableTxn) ApproximateDataSize() int64 {
+	var size int64
+	for _, row := range t.Rows {
+		size += row.ApproximateDataSize
+	}
+	return size
+}
+
 // TrySplitAndSortUpdateEvent split update events if unique key is updated
 func (t *SingleTableTxn) TrySplitAndSortUpdateEvent(scheme string) error {
 	if !t.shouldSplitUpdateEvent(scheme) {
@@ -57,6 +57,10 @@ func TestSetFlag(t *testing.T) {
 	require.True(t, flag.IsNullable())
 	flag.UnsetIsNullable()
 	require.False(t, flag.IsNullable())
+	flag.SetIsUnknownValue()
+	require.True(t, flag.IsUnknownValue())
+	flag.UnsetIsUnknownValue()
+	require.False(t, flag.IsUnknownValue())
 }
 
 func TestFlagValue(t *testing.T) {
@@ -609,3 +613,63 @@ func TestTrySplitAndSortUpdateEventOne(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, txn.Rows, 1)
 }
+
+func TestSingleTableTxnApproximateDataSize(t *testing.T) {
+	t.Parallel()
+
+	txn := &SingleTableTxn{}
+	require.Equal(t, int64(0), txn.ApproximateDataSize())
+
+	txn.Rows = append(txn.Rows, &RowChangedEvent{ApproximateDataSize: 100})
+	require.Equal(t, int64(100), txn.ApproximateDataSize())
+
+	txn.Rows = append(txn.Rows,
+		&RowChangedEvent{ApproximateDataSize: 50},
+		&RowChangedEvent{ApproximateDataSize: 30})
+	require.Equal(t, int64(180), txn.ApproximateDataSize())
+}
+
+func TestTrySplitAndSortUpdateEventSwapUniqueKey(t *testing.T) {
+	t.Parallel()
+
+	// Two rows swap the values of a unique key which is not the primary key.
+	newUpdate := func(id int, preUK, uk string) *RowChangedEvent {
+		return &RowChangedEvent{
+			CommitTs: 1,
+			PreColumns: []*Column{
+				{Name: "id", Flag: HandleKeyFlag | PrimaryKeyFlag, Value: id},
+				{Name: "uk", Flag: UniqueKeyFlag, Value: preUK},
+			},
+			Columns: []*Column{
+				{Name: "id", Flag: HandleKeyFlag | PrimaryKeyFlag, Value: id},
+				{Name: "uk", Flag: UniqueKeyFlag, Value: uk},
+			},
+		}
+	}
+	txn := &SingleTableTxn{
+		Rows: []*RowChangedEvent{newUpdate(1, "a", "b"), newUpdate(2, "b", "a")},
+	}
+	err := txn.TrySplitAndSortUpdateEvent(sink.MySQLScheme)
+	require.NoError(t, err)
+	require.Len(t, txn.Rows, 4)
+
+	// All old unique key images are deleted before any new one is inserted.
+	require.True(t, txn.Rows[0].IsDelete())
+	require.True(t, txn.Rows[1].IsDelete())
+	require.True(t, txn.Rows[2].IsInsert())
+	require.True(t, txn.Rows[3].IsInsert())
+	require.ElementsMatch(t, []interface{}{"a", "b"},
+		[]interface{}{txn.Rows[0].PreColumns[1].Value, txn.Rows[1].PreColumns[1].Value})
+	require.ElementsMatch(t, []interface{}{"a", "b"},
+		[]interface{}{txn.Rows[2].Columns[1].Value, txn.Rows[3].Columns[1].Value})
+
+	// An update which keeps the unique key is not split.
+	txn = &SingleTableTxn{
+		Rows: []*RowChangedEvent{newUpdate(1, "a", "a"), newUpdate(2, "b", "b")},
+	}
+	err = txn.TrySplitAndSortUpdateEvent(sink.MySQLScheme)
+	require.NoError(t, err)
+	require.Len(t, txn.Rows, 2)
+	require.True(t, txn.Rows[0].IsUpdate())
+	require.True(t, txn.Rows[1].IsUpdate())
+}
@@ -542,6 +542,26 @@ func TestAddSpecialComment(t *testing.T) {
 			result: "ALTER TABLE `t` ADD INDEX `j`((CAST(JSON_EXTRACT(`j`, _UTF8MB4'$.number[*]') " +
 				"AS SIGNED ARRAY)))",
 		},
+		{
+			event: &model.DDLEvent{
+				Query: "create table t(id bigint primary key auto_random(5))",
+			},
+			result: "CREATE TABLE `t` (`id` BIGINT PRIMARY KEY /*T![auto_rand] AUTO_RANDOM(5) */)",
+		},
+		{
+			event: &model.DDLEvent{
+				Query: "create table t(id bigint primary key /*T![auto_rand] auto_random(5) */)",
+			},
+			result: "CREATE TABLE `t` (`id` BIGINT PRIMARY KEY /*T![auto_rand] AUTO_RANDOM(5) */)",
+		},
+		{
+			event: &model.DDLEvent{
+				Query: "create table t(id int, primary key(id) /*T![clustered_index] NONCLUSTERED */) " +
+					"shard_row_id_bits = 4",
+			},
+			result: "CREATE TABLE `t` (`id` INT,PRIMARY KEY(`id`) " +
+				"/*T![clustered_index] NONCLUSTERED */) /*T! SHARD_ROW_ID_BITS = 4 */",
+		},
 	}
 
 	s := &ddlSinkImpl{}
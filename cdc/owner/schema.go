@@ -91,6 +91,10 @@ func (s *schemaWrap4Owner) AllPhysicalTables(
 		if s.shouldIgnoreTable(tblInfo) {
 			return
 		}
+		// A view has no row data, only its DDLs are replicated.
+		if tblInfo.IsView() {
+			return
+		}
 		if pi := tblInfo.GetPartitionInfo(); pi != nil {
 			for _, partition := range pi.Definitions {
 				res = append(res, partition.ID)
@@ -59,6 +59,12 @@ func TestAllPhysicalTables(t *testing.T) {
 	tableIDs, err = schema.AllPhysicalTables(context.Background(), job.BinlogInfo.FinishedTS)
 	require.Nil(t, err)
 	require.Equal(t, tableIDs, []model.TableID{tableIDT1})
+	// add view, which has no rows to replicate
+	job = helper.DDL2Job("create view test.v1 as select * from test.t1")
+	require.Nil(t, schema.HandleDDLJob(job))
+	tableIDs, err = schema.AllPhysicalTables(context.Background(), job.BinlogInfo.FinishedTS)
+	require.Nil(t, err)
+	require.Equal(t, tableIDs, []model.TableID{tableIDT1})
 	// add partition table
 	job = helper.DDL2Job(`CREATE TABLE test.employees  (
 			id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -238,6 +244,19 @@ func TestBuildDDLEventsFromSingleTableDDL(t *testing.T) {
 			},
 		},
 	})
+	require.Nil(t, schema.HandleDDLJob(job))
+	// the schema and table names come from the job even if the query is not qualified.
+	helper.Tk().MustExec("use test")
+	job = helper.DDL2Job("ALTER TABLE t1 ADD COLUMN c2 INT")
+	schema.AdvanceResolvedTs(job.BinlogInfo.FinishedTS - 1)
+	events, err = schema.BuildDDLEvents(ctx, job)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "ALTER TABLE t1 ADD COLUMN c2 INT", events[0].Query)
+	require.Equal(t, "test", events[0].TableInfo.TableName.Schema)
+	require.Equal(t, "t1", events[0].TableInfo.TableName.Table)
+	require.Equal(t, "test", events[0].PreTableInfo.TableName.Schema)
+	require.Equal(t, "t1", events[0].PreTableInfo.TableName.Table)
 }
 
 func TestBuildDDLEventsFromRenameTablesDDL(t *testing.T) {
@@ -658,7 +658,8 @@ func (p *processor) lazyInitImpl(etcdCtx cdcContext.Context) (err error) {
 
 	p.mg.r = entry.NewMounterGroup(p.ddlHandler.r.schemaStorage,
 		p.changefeed.Info.Config.Mounter.WorkerNum,
-		p.filter, tz, p.changefeedID, p.changefeed.Info.Config.Integrity)
+		p.filter, tz, p.changefeedID, p.changefeed.Info.Config.Integrity,
+		p.changefeed.Info.Config.Mounter.FullRowImage)
 	p.mg.name = "MounterGroup"
 	p.mg.changefeedID = p.changefeedID
 	p.mg.spawn(prcCtx)
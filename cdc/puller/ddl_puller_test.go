@@ -562,6 +562,24 @@ func TestHandleJob(t *testing.T) {
 		require.True(t, skip)
 	}
 
+	// test sequence, which is discarded even if the table filter matches it.
+	{
+		job := helper.DDL2Job("create sequence test1.t2")
+		skip, err := ddlJobPullerImpl.handleJob(job)
+		require.NoError(t, err)
+		require.True(t, skip)
+
+		job = helper.DDL2Job("alter sequence test1.t2 increment by 2")
+		skip, err = ddlJobPullerImpl.handleJob(job)
+		require.NoError(t, err)
+		require.True(t, skip)
+
+		job = helper.DDL2Job("drop sequence test1.t2")
+		skip, err = ddlJobPullerImpl.handleJob(job)
+		require.NoError(t, err)
+		require.True(t, skip)
+	}
+
 	// test flashback cluster
 	{
 		// mock a flashback job
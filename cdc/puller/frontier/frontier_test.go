@@ -391,3 +391,32 @@ func TestMinMaxWithRegionSplitMerge(t *testing.T) {
 	f.Forward(8, tablepb.Span{StartKey: []byte("d"), EndKey: []byte("e")}, 5)
 	require.Equal(t, uint64(5), f.Frontier())
 }
+
+func TestSpanFrontierSplitInheritsParentTs(t *testing.T) {
+	t.Parallel()
+
+	ab := tablepb.Span{StartKey: []byte("a"), EndKey: []byte("b")}
+	bc := tablepb.Span{StartKey: []byte("b"), EndKey: []byte("c")}
+	ac := tablepb.Span{StartKey: []byte("a"), EndKey: []byte("c")}
+
+	f := NewFrontier(0, ac)
+	f.Forward(1, ac, 5)
+	require.Equal(t, uint64(5), f.Frontier())
+
+	// Region 1 splits into region 2 and region 3, the child which has not
+	// reported yet inherits the resolved ts of its parent.
+	f.Forward(2, ab, 6)
+	require.Equal(t, uint64(5), f.Frontier())
+	require.Equal(t, `[a @ 6] [b @ 5] [c @ Max] `, f.String())
+	checkFrontier(t, f)
+
+	f.Forward(3, bc, 7)
+	require.Equal(t, uint64(6), f.Frontier())
+	checkFrontier(t, f)
+
+	// Region 2 and region 3 merge into region 4.
+	f.Forward(4, ac, 8)
+	require.Equal(t, uint64(8), f.Frontier())
+	require.Equal(t, `[a @ 8] [c @ Max] `, f.String())
+	checkFrontier(t, f)
+}
@@ -153,3 +153,36 @@ func TestIndexValueDispatcher(t *testing.T) {
 		require.Equal(t, tc.expectPartition, index)
 	}
 }
+
+func TestIndexValueDispatcherSameHandleKey(t *testing.T) {
+	t.Parallel()
+
+	table := &model.TableName{Schema: "test", Table: "t1"}
+	newColumns := func(a, b int) []*model.Column {
+		return []*model.Column{
+			{Name: "a", Value: a, Flag: model.HandleKeyFlag},
+			{Name: "b", Value: b, Flag: 0},
+		}
+	}
+	p := NewIndexValueDispatcher()
+
+	// All changes of one row are dispatched to the same partition,
+	// whatever the values of the other columns are.
+	insert := &model.RowChangedEvent{Table: table, Columns: newColumns(11, 1)}
+	expected, _ := p.DispatchRowChangedEvent(insert, 16)
+	for _, row := range []*model.RowChangedEvent{
+		{Table: table, PreColumns: newColumns(11, 1), Columns: newColumns(11, 2)},
+		{Table: table, PreColumns: newColumns(11, 2), Columns: newColumns(11, 3)},
+		{Table: table, PreColumns: newColumns(11, 3)},
+	} {
+		index, _ := p.DispatchRowChangedEvent(row, 16)
+		require.Equal(t, expected, index)
+	}
+
+	// Rows without handle key columns are dispatched by the table.
+	noHandle := []*model.Column{{Name: "a", Value: 11}, {Name: "b", Value: 1}}
+	expected, _ = p.DispatchRowChangedEvent(&model.RowChangedEvent{Table: table, Columns: noHandle}, 16)
+	noHandle = []*model.Column{{Name: "a", Value: 22}, {Name: "b", Value: 2}}
+	index, _ := p.DispatchRowChangedEvent(&model.RowChangedEvent{Table: table, Columns: noHandle}, 16)
+	require.Equal(t, expected, index)
+}
@@ -47,6 +47,28 @@ func TestGenKeyListCaseInSensitive(t *testing.T) {
 	require.Equal(t, first, second)
 }
 
+func TestGenKeyListCollations(t *testing.T) {
+	t.Parallel()
+
+	genKey := func(value string, collation string) []byte {
+		columns := []*model.Column{{
+			Value:     value,
+			Type:      mysql.TypeVarchar,
+			Collation: collation,
+		}}
+		return genKeyList(columns, 0, []int{0}, 1)
+	}
+
+	for _, collation := range []string{
+		"utf8mb4_general_ci", "gbk_chinese_ci", "latin1_swedish_ci",
+	} {
+		require.Equal(t, genKey("AbC", collation), genKey("aBc", collation), collation)
+	}
+	for _, collation := range []string{"utf8mb4_bin", "gbk_bin", "latin1_bin", ""} {
+		require.NotEqual(t, genKey("AbC", collation), genKey("aBc", collation), collation)
+	}
+}
+
 func TestGenKeys(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
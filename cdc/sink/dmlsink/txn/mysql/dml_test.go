@@ -16,9 +16,11 @@ package mysql
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/quotes"
 	"github.com/stretchr/testify/require"
 )
 
@@ -719,3 +721,40 @@ func TestMapReplace(t *testing.T) {
 		}
 	}
 }
+
+func TestPrepareDMLWithSpecialIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	quoteTable := quotes.QuoteSchema("te`st", "order")
+	preCols := []*model.Column{
+		{
+			Name:  "sel`ect",
+			Type:  mysql.TypeLong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: 1,
+		},
+		{Name: "order", Type: mysql.TypeVarchar, Value: "a"},
+	}
+	cols := []*model.Column{
+		{
+			Name:  "sel`ect",
+			Type:  mysql.TypeLong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: 1,
+		},
+		{Name: "order", Type: mysql.TypeVarchar, Value: "b"},
+	}
+
+	replaceSQL, _ := prepareReplace(quoteTable, cols, true, false)
+	require.Equal(t, "REPLACE INTO `te``st`.`order` (`sel``ect`,`order`) VALUES (?,?)", replaceSQL)
+	updateSQL, _ := prepareUpdate(quoteTable, preCols, cols, false)
+	require.Equal(t, "UPDATE `te``st`.`order` SET `sel``ect`=?,`order`=? WHERE `sel``ect`=? LIMIT 1", updateSQL)
+	deleteSQL, _ := prepareDelete(quoteTable, preCols, false)
+	require.Equal(t, "DELETE FROM `te``st`.`order` WHERE `sel``ect` = ? LIMIT 1", deleteSQL)
+
+	p := parser.New()
+	for _, sql := range []string{replaceSQL, updateSQL, deleteSQL} {
+		_, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err, sql)
+	}
+}
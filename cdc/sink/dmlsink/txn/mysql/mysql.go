@@ -67,6 +67,8 @@ type mysqlBackend struct {
 
 	events []*dmlsink.TxnCallbackableEvent
 	rows   int
+	// size is the approximate data size of the buffered rows.
+	size int64
 
 	statistics                      *metrics.Statistics
 	metricTxnSinkDMLBatchCommit     prometheus.Observer
@@ -201,10 +203,14 @@ func NewMySQLBackends(
 
 // OnTxnEvent implements interface backend.
 // It adds the event to the buffer, and return true if it needs flush immediately.
+// A transaction larger than the limits is still buffered as a whole, and it
+// will be flushed right after it is added.
 func (s *mysqlBackend) OnTxnEvent(event *dmlsink.TxnCallbackableEvent) (needFlush bool) {
 	s.events = append(s.events, event)
 	s.rows += len(event.Event.Rows)
-	return event.Event.ToWaitFlush() || s.rows >= s.cfg.MaxTxnRow
+	s.size += event.Event.ApproximateDataSize()
+	return event.Event.ToWaitFlush() || s.rows >= s.cfg.MaxTxnRow ||
+		(s.cfg.MaxTxnSize > 0 && s.size >= int64(s.cfg.MaxTxnSize))
 }
 
 // Flush implements interface backend.
@@ -251,6 +257,7 @@ func (s *mysqlBackend) Flush(ctx context.Context) (err error) {
 	}
 	s.events = s.events[:0]
 	s.rows = 0
+	s.size = 0
 	return
 }
 
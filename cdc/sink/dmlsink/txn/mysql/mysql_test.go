@@ -1177,6 +1177,78 @@ func TestMysqlSinkSafeModeOff(t *testing.T) {
 	}
 }
 
+func TestPrepareDMLsSafeMode(t *testing.T) {
+	t.Parallel()
+
+	columns := []*model.Column{nil, {
+		Name:  "a1",
+		Type:  mysql.TypeLong,
+		Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+		Value: 1,
+	}, {
+		Name:  "a3",
+		Type:  mysql.TypeLong,
+		Flag:  model.BinaryFlag | model.MultipleKeyFlag | model.HandleKeyFlag,
+		Value: 1,
+	}}
+	insertRow := &model.RowChangedEvent{
+		StartTs:       418658114257813514,
+		CommitTs:      418658114257813515,
+		ReplicatingTs: 418658114257813513,
+		Table:         &model.TableName{Schema: "common_1", Table: "pk"},
+		Columns:       columns,
+	}
+	deleteRow := &model.RowChangedEvent{
+		StartTs:       418658114257813514,
+		CommitTs:      418658114257813515,
+		ReplicatingTs: 418658114257813513,
+		Table:         &model.TableName{Schema: "common_1", Table: "pk"},
+		PreColumns:    columns,
+	}
+
+	testCases := []struct {
+		name     string
+		safeMode bool
+		input    *model.RowChangedEvent
+		expected string
+	}{
+		{
+			name:     "insert",
+			safeMode: false,
+			input:    insertRow,
+			expected: "INSERT INTO `common_1`.`pk` (`a1`,`a3`) VALUES (?,?)",
+		}, {
+			name:     "insert in safe mode",
+			safeMode: true,
+			input:    insertRow,
+			expected: "REPLACE INTO `common_1`.`pk` (`a1`,`a3`) VALUES (?,?)",
+		}, {
+			name:     "delete",
+			safeMode: false,
+			input:    deleteRow,
+			expected: "DELETE FROM `common_1`.`pk` WHERE `a1` = ? AND `a3` = ? LIMIT 1",
+		}, {
+			// a DELETE already tolerates a missing row, so it is not rewritten.
+			name:     "delete in safe mode",
+			safeMode: true,
+			input:    deleteRow,
+			expected: "DELETE FROM `common_1`.`pk` WHERE `a1` = ? AND `a3` = ? LIMIT 1",
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms := newMySQLBackendWithoutDB(ctx)
+	for _, tc := range testCases {
+		ms.cfg.SafeMode = tc.safeMode
+		ms.events = []*dmlsink.TxnCallbackableEvent{{
+			Event: &model.SingleTableTxn{Rows: []*model.RowChangedEvent{tc.input}},
+		}}
+		ms.rows = 1
+		dmls := ms.prepareDMLs()
+		require.Equal(t, []string{tc.expected}, dmls.sqls, tc.name)
+	}
+}
+
 func TestPrepareBatchDMLs(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -1940,3 +2012,46 @@ func TestBackendGenUpdateSQL(t *testing.T) {
 		require.Equal(t, tc.expectedValues, values)
 	}
 }
+
+func TestOnTxnEventNeedFlush(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newTxn := func(rows, size int) *dmlsink.TxnCallbackableEvent {
+		txn := &model.SingleTableTxn{}
+		for i := 0; i < rows; i++ {
+			txn.Rows = append(txn.Rows, &model.RowChangedEvent{ApproximateDataSize: int64(size)})
+		}
+		return &dmlsink.TxnCallbackableEvent{Event: txn}
+	}
+
+	// Flush by rows.
+	ms := newMySQLBackendWithoutDB(ctx)
+	ms.cfg.MaxTxnRow = 4
+	require.False(t, ms.OnTxnEvent(newTxn(2, 10)))
+	require.True(t, ms.OnTxnEvent(newTxn(2, 10)))
+
+	// Flush by size, once the size reaches the limit.
+	ms = newMySQLBackendWithoutDB(ctx)
+	ms.cfg.MaxTxnSize = 100
+	require.False(t, ms.OnTxnEvent(newTxn(1, 60)))
+	require.False(t, ms.OnTxnEvent(newTxn(1, 39)))
+	require.True(t, ms.OnTxnEvent(newTxn(1, 1)))
+	require.Len(t, ms.events, 3)
+	require.Equal(t, int64(100), ms.size)
+
+	// Never flush by size if MaxTxnSize is 0.
+	ms = newMySQLBackendWithoutDB(ctx)
+	require.Equal(t, 0, ms.cfg.MaxTxnSize)
+	for i := 0; i < 3; i++ {
+		require.False(t, ms.OnTxnEvent(newTxn(1, 1024*1024)))
+	}
+	require.Len(t, ms.events, 3)
+	require.Equal(t, int64(3*1024*1024), ms.size)
+
+	// A single transaction larger than the limit is flushed as a whole.
+	ms = newMySQLBackendWithoutDB(ctx)
+	ms.cfg.MaxTxnSize = 100
+	require.True(t, ms.OnTxnEvent(newTxn(3, 50)))
+	require.Len(t, ms.events, 1)
+}
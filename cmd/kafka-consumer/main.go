@@ -157,6 +157,12 @@ func (o *consumerOption) Adjust(upstreamURI *url.URL, configFile string) error {
 		if err != nil {
 			log.Panic("invalid protocol", zap.Error(err), zap.String("protocol", s))
 		}
+		// The debezium protocol is encode-only, its messages can not be
+		// decoded back into row changed events.
+		if protocol == config.ProtocolDebezium {
+			log.Panic("debezium protocol is not supported by the consumer",
+				zap.String("protocol", s))
+		}
 		o.protocol = protocol
 	}
 
@@ -2684,6 +2684,9 @@ var doc = `{
                 "max_txn_row": {
                     "type": "integer"
                 },
+                "max_txn_size": {
+                    "type": "integer"
+                },
                 "read_timeout": {
                     "type": "string"
                 },
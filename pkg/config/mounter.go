@@ -16,4 +16,8 @@ package config
 // MounterConfig represents mounter config for a changefeed
 type MounterConfig struct {
 	WorkerNum int `toml:"worker-num" json:"worker-num"`
+	// FullRowImage marks the columns of the before image of a row that are
+	// not present in its old value as unknown, instead of filling them with
+	// the default value of the column.
+	FullRowImage bool `toml:"full-row-image" json:"full-row-image,omitempty"`
 }
@@ -541,6 +541,7 @@ func (c *PulsarConfig) GetDefaultTopicName() string {
 type MySQLConfig struct {
 	WorkerCount                  *int    `toml:"worker-count" json:"worker-count,omitempty"`
 	MaxTxnRow                    *int    `toml:"max-txn-row" json:"max-txn-row,omitempty"`
+	MaxTxnSize                   *int    `toml:"max-txn-size" json:"max-txn-size,omitempty"`
 	MaxMultiUpdateRowSize        *int    `toml:"max-multi-update-row-size" json:"max-multi-update-row-size,omitempty"`
 	MaxMultiUpdateRowCount       *int    `toml:"max-multi-update-row" json:"max-multi-update-row,omitempty"`
 	TiDBTxnMode                  *string `toml:"tidb-txn-mode" json:"tidb-txn-mode,omitempty"`
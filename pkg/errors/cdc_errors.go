@@ -367,6 +367,10 @@ var (
 		"maxwell encode failed",
 		errors.RFCCodeText("CDC:ErrMaxwellEncodeFailed"),
 	)
+	ErrDebeziumEncodeFailed = errors.Normalize(
+		"debezium encode failed",
+		errors.RFCCodeText("CDC:ErrDebeziumEncodeFailed"),
+	)
 	ErrMaxwellInvalidData = errors.Normalize(
 		"maxwell invalid data",
 		errors.RFCCodeText("CDC:ErrMaxwellInvalidData"),
@@ -84,6 +84,24 @@ func TestShouldUseCustomRules(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestShouldIgnoreTableCaseSensitive(t *testing.T) {
+	t.Parallel()
+
+	for _, caseSensitive := range []bool{false, true} {
+		filter, err := NewFilter(&config.ReplicaConfig{
+			CaseSensitive: caseSensitive,
+			Filter: &config.FilterConfig{
+				Rules: []string{"TestDB.Test1"},
+			},
+		}, "")
+		require.Nil(t, err)
+		require.False(t, filter.ShouldIgnoreTable("TestDB", "Test1"))
+		require.Equal(t, caseSensitive, filter.ShouldIgnoreTable("testdb", "test1"))
+		require.Equal(t, caseSensitive, filter.ShouldIgnoreTable("TESTDB", "TEST1"))
+		require.True(t, filter.ShouldIgnoreTable("testdb", "test2"))
+	}
+}
+
 func TestShouldIgnoreDMLEvent(t *testing.T) {
 	t.Parallel()
 
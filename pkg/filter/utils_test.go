@@ -70,6 +70,41 @@ func TestVerifyTableRules(t *testing.T) {
 	}
 }
 
+func TestVerifyTableRulesMatch(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		rules    []string
+		schema   string
+		table    string
+		expected bool
+	}{
+		{[]string{"testDB.*", "!testDB.temp_*"}, "testDB", "t1", true},
+		{[]string{"testDB.*", "!testDB.temp_*"}, "testDB", "temp_1", false},
+		{[]string{"testDB.*", "!testDB.temp_*"}, "otherDB", "t1", false},
+		{[]string{"*.*", "!testDB.temp_*"}, "otherDB", "temp_1", true},
+		{[]string{"*.*"}, "testDB", "temp_1", true},
+		{[]string{"test?.t[0-9]"}, "test1", "t2", true},
+		{[]string{"test?.t[0-9]"}, "test1", "tx", false},
+	}
+	for _, c := range cases {
+		f, err := VerifyTableRules(&config.FilterConfig{Rules: c.rules})
+		require.NoError(t, err, "case: %s", c.rules)
+		require.Equal(t, c.expected, f.MatchTable(c.schema, c.table),
+			"case: %s, %s.%s", c.rules, c.schema, c.table)
+	}
+
+	for _, rules := range [][]string{
+		{"testDB.t[9-0]"},
+		{"testDB./temp_"},
+		{`testDB.t\`},
+		{"testDB"},
+		{"testDB.*", "!"},
+	} {
+		_, err := VerifyTableRules(&config.FilterConfig{Rules: rules})
+		require.Regexp(t, ".*CDC:ErrFilterRuleInvalid.*", err, "case: %s", rules)
+	}
+}
+
 func TestDDLToEventType(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -851,6 +851,42 @@ func TestAvroEncode(t *testing.T) {
 	}
 }
 
+func TestAvroEncodeDeleteAsTombstone(t *testing.T) {
+	codecConfig := common.NewConfig(config.ProtocolAvro)
+	codecConfig.EnableTiDBExtension = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	encoder, err := SetupEncoderAndSchemaRegistry4Testing(ctx, codecConfig)
+	defer TeardownEncoderAndSchemaRegistry4Testing()
+	require.NoError(t, err)
+	require.NotNil(t, encoder)
+
+	event := newLargeEvent()
+	deleteEvent := *event
+	deleteEvent.PreColumns = event.Columns
+	deleteEvent.Columns = nil
+
+	topic := "default"
+	err = encoder.AppendRowChangedEvent(ctx, topic, &deleteEvent, nil)
+	require.NoError(t, err)
+	msgs := encoder.Build()
+	require.Len(t, msgs, 1)
+	// a delete is encoded as a tombstone, which has a key and a nil value,
+	// so that it can be compacted away by a log compacted topic.
+	require.Nil(t, msgs[0].Value)
+	require.NotEmpty(t, msgs[0].Key)
+
+	cid, data, err := extractConfluentSchemaIDAndBinaryData(msgs[0].Key)
+	require.NoError(t, err)
+	avroKeyCodec, err := encoder.schemaM.Lookup(ctx, topic, schemaID{confluentSchemaID: cid})
+	require.NoError(t, err)
+	res, _, err := avroKeyCodec.NativeFromBinary(data)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), res.(map[string]interface{})["id"])
+}
+
 func TestAvroEnvelope(t *testing.T) {
 	t.Parallel()
 	cManager := &confluentSchemaManager{}
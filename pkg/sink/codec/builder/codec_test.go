@@ -64,6 +64,50 @@ func encodeRowCase(t *testing.T, encoder codec.RowEventEncoder,
 	return msg
 }
 
+func TestNewRowEventEncoderBuilder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	event := internal.CodecRowCases[0][0]
+	for _, protocol := range []config.Protocol{
+		config.ProtocolOpen,
+		config.ProtocolCanal,
+		config.ProtocolMaxwell,
+		config.ProtocolCanalJSON,
+		config.ProtocolCraft,
+		config.ProtocolDebezium,
+	} {
+		builder, err := NewRowEventEncoderBuilder(ctx, common.NewConfig(protocol))
+		require.NoError(t, err, protocol.String())
+		messages := encodeRowCase(t, builder.Build(), []*model.RowChangedEvent{event})
+		require.Len(t, messages, 1, protocol.String())
+		require.NotEmpty(t, messages[0].Value, protocol.String())
+	}
+
+	_, err := NewRowEventEncoderBuilder(ctx, common.NewConfig(config.ProtocolCsv))
+	require.ErrorContains(t, err, "unknown")
+
+	// the event encoded by the resolved encoder can be decoded back.
+	codecConfig := common.NewConfig(config.ProtocolOpen)
+	builder, err := NewRowEventEncoderBuilder(ctx, codecConfig)
+	require.NoError(t, err)
+	messages := encodeRowCase(t, builder.Build(), []*model.RowChangedEvent{event})
+	decoder, err := open.NewBatchDecoder(ctx, codecConfig, nil)
+	require.NoError(t, err)
+	err = decoder.AddKeyValue(messages[0].Key, messages[0].Value)
+	require.NoError(t, err)
+	tp, hasNext, err := decoder.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, tp)
+	decoded, err := decoder.NextRowChangedEvent()
+	require.NoError(t, err)
+	require.Equal(t, event.CommitTs, decoded.CommitTs)
+	require.Equal(t, event.Table.Schema, decoded.Table.Schema)
+	require.Equal(t, event.Table.Table, decoded.Table.Table)
+	require.Len(t, decoded.Columns, len(event.Columns))
+}
+
 func TestJsonVsCraftVsPB(t *testing.T) {
 	t.Parallel()
 	t.Logf("| case | craft size | json size | protobuf 1 size | protobuf 2 size | craft compressed | json compressed | protobuf 1 compressed | protobuf 2 compressed |")
@@ -14,6 +14,8 @@
 package canal
 
 import (
+	"math"
+	"strconv"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -45,6 +47,38 @@ func TestGetMySQLTypeAndJavaSQLType(t *testing.T) {
 	}
 }
 
+func TestFormatFloatValue(t *testing.T) {
+	t.Parallel()
+	builder := newCanalEntryBuilder()
+
+	cases := []struct {
+		value    float64
+		expected string
+	}{
+		{0.1, "0.1"},
+		{1e-7, "0.0000001"},
+		{math.Copysign(0, -1), "-0"},
+		{-123.456, "-123.456"},
+		{math.SmallestNonzeroFloat64, ""},
+		{math.MaxFloat64, ""},
+	}
+	for _, cs := range cases {
+		result, err := builder.formatValue(cs.value, internal.JavaSQLTypeDOUBLE)
+		require.NoError(t, err)
+		if cs.expected != "" {
+			require.Equal(t, cs.expected, result)
+		}
+		// the formatted value must reproduce the exact float.
+		parsed, err := strconv.ParseFloat(result, 64)
+		require.NoError(t, err)
+		require.Equal(t, math.Float64bits(cs.value), math.Float64bits(parsed))
+	}
+
+	result, err := builder.formatValue(float32(0.1), internal.JavaSQLTypeREAL)
+	require.NoError(t, err)
+	require.Equal(t, "0.1", result)
+}
+
 func TestConvertEntry(t *testing.T) {
 	t.Parallel()
 	testInsert(t)
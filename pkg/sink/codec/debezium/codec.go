@@ -0,0 +1,525 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson/jwriter"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// sourceVersion is the Debezium version the source block is compatible with.
+	sourceVersion = "2.4.0.Final"
+	// sourceConnector is the connector name carried in the source block.
+	sourceConnector = "TiCDC"
+
+	// dateLayout is the layout of the DATE values mounted by TiCDC.
+	dateLayout = "2006-01-02"
+	// timeLayout is the layout of the DATETIME and TIMESTAMP values mounted
+	// by TiCDC. The fractional seconds are optional.
+	timeLayout = "2006-01-02 15:04:05.999999999"
+)
+
+// dbzCodec converts row changed events to Debezium JSON messages.
+type dbzCodec struct {
+	config *common.Config
+	// tz is the time zone in which the TIMESTAMP values are mounted.
+	tz      *time.Location
+	nowFunc func() time.Time
+}
+
+// encodeKey writes the key of the row changed event, which contains the
+// handle key columns of the row, in Debezium's key format. Nothing is
+// written if the table has no handle key.
+func (c *dbzCodec) encodeKey(e *model.RowChangedEvent, out *jwriter.Writer) {
+	columns := e.Columns
+	if e.IsDelete() {
+		columns = e.PreColumns
+	}
+	keyColumns, keyColumnTypes := handleKeyColumns(columns, e.ColInfos)
+	if len(keyColumns) == 0 {
+		return
+	}
+
+	out.RawString(`{"schema":`)
+	c.writeColumnsSchema(
+		out, fmt.Sprintf("%s.Key", c.schemaNamePrefix(e)), "", false,
+		keyColumns, keyColumnTypes)
+	out.RawString(`,"payload":`)
+	c.writeColumnsValue(out, keyColumns, keyColumnTypes)
+	out.RawByte('}')
+}
+
+// encodeValue writes the row changed event in Debezium's envelope format.
+func (c *dbzCodec) encodeValue(e *model.RowChangedEvent, out *jwriter.Writer) {
+	var op string
+	var before, after []*model.Column
+	switch {
+	case e.IsInsert():
+		op = "c"
+		after = e.Columns
+	case e.IsDelete():
+		op = "d"
+		before = e.PreColumns
+	default:
+		op = "u"
+		before = e.PreColumns
+		after = e.Columns
+	}
+	// The columns of the before and the after image share the same schema.
+	columns := e.Columns
+	if e.IsDelete() {
+		columns = e.PreColumns
+	}
+	columnTypes := make([]*types.FieldType, len(columns))
+	for i := range columns {
+		columnTypes[i] = fieldTypeAt(e.ColInfos, i)
+	}
+	if e.IsDelete() && c.config.DeleteOnlyHandleKeyColumns {
+		// Only the handle key columns are sent, so the schema must not
+		// declare the other columns, some of which may not be optional.
+		columns, columnTypes = handleKeyColumns(columns, e.ColInfos)
+		before = columns
+	}
+	valueSchemaName := fmt.Sprintf("%s.Value", c.schemaNamePrefix(e))
+
+	out.RawString(`{"schema":{"type":"struct","optional":false,"name":`)
+	out.String(fmt.Sprintf("%s.Envelope", c.schemaNamePrefix(e)))
+	out.RawString(`,"fields":[`)
+	c.writeColumnsSchema(out, valueSchemaName, "before", true, columns, columnTypes)
+	out.RawByte(',')
+	c.writeColumnsSchema(out, valueSchemaName, "after", true, columns, columnTypes)
+	out.RawString(`,{"type":"struct","optional":false,` +
+		`"name":"io.debezium.connector.mysql.Source","field":"source","fields":[` +
+		`{"type":"string","optional":false,"field":"version"},` +
+		`{"type":"string","optional":false,"field":"connector"},` +
+		`{"type":"string","optional":false,"field":"name"},` +
+		`{"type":"int64","optional":false,"field":"ts_ms"},` +
+		`{"type":"string","optional":true,"field":"snapshot"},` +
+		`{"type":"string","optional":false,"field":"db"},` +
+		`{"type":"string","optional":true,"field":"table"},` +
+		`{"type":"int64","optional":false,"field":"commit_ts"}]},` +
+		`{"type":"string","optional":false,"field":"op"},` +
+		`{"type":"int64","optional":true,"field":"ts_ms"},` +
+		// The transaction metadata is not provided, so the block is always null.
+		`{"type":"struct","optional":true,"name":"event.block","field":"transaction","fields":[` +
+		`{"type":"string","optional":false,"field":"id"},` +
+		`{"type":"int64","optional":false,"field":"total_order"},` +
+		`{"type":"int64","optional":false,"field":"data_collection_order"}]}]}`)
+
+	out.RawString(`,"payload":{"before":`)
+	c.writeColumnsValue(out, before, columnTypes)
+	out.RawString(`,"after":`)
+	c.writeColumnsValue(out, after, columnTypes)
+	out.RawString(`,"source":{"version":`)
+	out.String(sourceVersion)
+	out.RawString(`,"connector":`)
+	out.String(sourceConnector)
+	out.RawString(`,"name":`)
+	out.String(c.config.ChangefeedID.ID)
+	out.RawString(`,"ts_ms":`)
+	out.Int64(oracle.ExtractPhysical(e.CommitTs))
+	out.RawString(`,"snapshot":"false","db":`)
+	out.String(e.Table.Schema)
+	out.RawString(`,"table":`)
+	out.String(e.Table.Table)
+	out.RawString(`,"commit_ts":`)
+	out.Uint64(e.CommitTs)
+	out.RawString(`},"op":`)
+	out.String(op)
+	out.RawString(`,"ts_ms":`)
+	out.Int64(c.nowFunc().UnixMilli())
+	out.RawString(`,"transaction":null}}`)
+}
+
+func (c *dbzCodec) schemaNamePrefix(e *model.RowChangedEvent) string {
+	return fmt.Sprintf("%s.%s.%s", c.config.ChangefeedID.ID, e.Table.Schema, e.Table.Table)
+}
+
+// writeColumnsSchema writes a Kafka Connect struct schema describing the columns.
+// The field name is omitted if it is empty.
+func (c *dbzCodec) writeColumnsSchema(
+	out *jwriter.Writer, name string, field string, optional bool,
+	columns []*model.Column, columnTypes []*types.FieldType,
+) {
+	out.RawString(`{"type":"struct","optional":`)
+	out.Bool(optional)
+	out.RawString(`,"name":`)
+	out.String(name)
+	if field != "" {
+		out.RawString(`,"field":`)
+		out.String(field)
+	}
+	out.RawString(`,"fields":[`)
+	isFirst := true
+	for i, col := range columns {
+		if col == nil {
+			continue
+		}
+		if isFirst {
+			isFirst = false
+		} else {
+			out.RawByte(',')
+		}
+		schema := newConnectSchema(col, columnTypes[i])
+		out.RawString(`{"type":`)
+		out.String(schema.typ)
+		out.RawString(`,"optional":`)
+		out.Bool(!col.Flag.IsHandleKey() && col.Flag.IsNullable())
+		if schema.name != "" {
+			out.RawString(`,"name":`)
+			out.String(schema.name)
+			out.RawString(`,"version":1`)
+		}
+		if len(schema.parameters) != 0 {
+			out.RawString(`,"parameters":{`)
+			for j := 0; j < len(schema.parameters); j += 2 {
+				if j > 0 {
+					out.RawByte(',')
+				}
+				out.String(schema.parameters[j])
+				out.RawByte(':')
+				out.String(schema.parameters[j+1])
+			}
+			out.RawByte('}')
+		}
+		out.RawString(`,"field":`)
+		out.String(col.Name)
+		out.RawByte('}')
+	}
+	out.RawString(`]}`)
+}
+
+// writeColumnsValue writes the columns as a JSON object, or null if there is no column.
+func (c *dbzCodec) writeColumnsValue(
+	out *jwriter.Writer, columns []*model.Column, columnTypes []*types.FieldType,
+) {
+	if len(columns) == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('{')
+	isFirst := true
+	for i, col := range columns {
+		if col == nil {
+			continue
+		}
+		if isFirst {
+			isFirst = false
+		} else {
+			out.RawByte(',')
+		}
+		out.String(col.Name)
+		out.RawByte(':')
+		c.writeColumnValue(out, col, columnTypes[i])
+	}
+	out.RawByte('}')
+}
+
+// handleKeyColumns returns the handle key columns and their field types.
+func handleKeyColumns(
+	columns []*model.Column, colInfos []rowcodec.ColInfo,
+) ([]*model.Column, []*types.FieldType) {
+	keyColumns := make([]*model.Column, 0, len(columns))
+	keyColumnTypes := make([]*types.FieldType, 0, len(columns))
+	for i, col := range columns {
+		if col != nil && col.Flag.IsHandleKey() {
+			keyColumns = append(keyColumns, col)
+			keyColumnTypes = append(keyColumnTypes, fieldTypeAt(colInfos, i))
+		}
+	}
+	return keyColumns, keyColumnTypes
+}
+
+// fieldTypeAt returns the field type of the i-th column, or nil if the
+// column info is not available.
+func fieldTypeAt(colInfos []rowcodec.ColInfo, i int) *types.FieldType {
+	if i < len(colInfos) {
+		return colInfos[i].Ft
+	}
+	return nil
+}
+
+// connectSchema is the Kafka Connect schema of a column.
+type connectSchema struct {
+	typ string
+	// name is the Debezium semantic type name, empty if there is none.
+	name string
+	// parameters holds the parameters of the semantic type as key value pairs.
+	parameters []string
+}
+
+// newConnectSchema returns the Kafka Connect schema of the column, following
+// the type mapping of the Debezium MySQL connector with its default options,
+// except that decimal columns are encoded as strings, like
+// decimal.handling.mode=string does.
+func newConnectSchema(col *model.Column, ft *types.FieldType) connectSchema {
+	switch col.Type {
+	case mysql.TypeTiny:
+		return connectSchema{typ: "int16"}
+	case mysql.TypeShort:
+		if col.Flag.IsUnsigned() {
+			return connectSchema{typ: "int32"}
+		}
+		return connectSchema{typ: "int16"}
+	case mysql.TypeInt24:
+		return connectSchema{typ: "int32"}
+	case mysql.TypeLong:
+		if col.Flag.IsUnsigned() {
+			return connectSchema{typ: "int64"}
+		}
+		return connectSchema{typ: "int32"}
+	case mysql.TypeLonglong:
+		// BIGINT UNSIGNED values are wrapped into int64, like
+		// bigint.unsigned.handling.mode=long does.
+		return connectSchema{typ: "int64"}
+	case mysql.TypeFloat, mysql.TypeDouble:
+		return connectSchema{typ: "float64"}
+	case mysql.TypeYear:
+		return connectSchema{typ: "int32", name: "io.debezium.time.Year"}
+	case mysql.TypeDate, mysql.TypeNewDate:
+		return connectSchema{typ: "int32", name: "io.debezium.time.Date"}
+	case mysql.TypeDuration:
+		return connectSchema{typ: "int64", name: "io.debezium.time.MicroTime"}
+	case mysql.TypeDatetime:
+		if ft != nil && ft.GetDecimal() > 3 {
+			return connectSchema{typ: "int64", name: "io.debezium.time.MicroTimestamp"}
+		}
+		return connectSchema{typ: "int64", name: "io.debezium.time.Timestamp"}
+	case mysql.TypeTimestamp:
+		return connectSchema{typ: "string", name: "io.debezium.time.ZonedTimestamp"}
+	case mysql.TypeEnum:
+		return connectSchema{
+			typ:        "string",
+			name:       "io.debezium.data.Enum",
+			parameters: []string{"allowed", allowedElems(ft)},
+		}
+	case mysql.TypeSet:
+		return connectSchema{
+			typ:        "string",
+			name:       "io.debezium.data.EnumSet",
+			parameters: []string{"allowed", allowedElems(ft)},
+		}
+	case mysql.TypeBit:
+		if ft == nil {
+			return connectSchema{typ: "bytes", name: "io.debezium.data.Bits"}
+		}
+		if ft.GetFlen() == 1 {
+			return connectSchema{typ: "boolean"}
+		}
+		return connectSchema{
+			typ:        "bytes",
+			name:       "io.debezium.data.Bits",
+			parameters: []string{"length", strconv.Itoa(ft.GetFlen())},
+		}
+	case mysql.TypeJSON:
+		return connectSchema{typ: "string", name: "io.debezium.data.Json"}
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if col.Flag.IsBinary() {
+			return connectSchema{typ: "bytes"}
+		}
+		return connectSchema{typ: "string"}
+	default:
+		return connectSchema{typ: "string"}
+	}
+}
+
+func allowedElems(ft *types.FieldType) string {
+	if ft == nil {
+		return ""
+	}
+	return strings.Join(ft.GetElems(), ",")
+}
+
+// writeColumnValue writes the value of the column in the representation of
+// the schema returned by newConnectSchema. Zero dates are written as null.
+// An error is set on the writer if the value does not have the type the
+// column type is mounted as.
+func (c *dbzCodec) writeColumnValue(
+	out *jwriter.Writer, col *model.Column, ft *types.FieldType,
+) {
+	if col.Value == nil {
+		out.RawString("null")
+		return
+	}
+	var err error
+	switch col.Type {
+	case mysql.TypeDate, mysql.TypeNewDate:
+		s, ok := col.Value.(string)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		var t time.Time
+		t, err = time.ParseInLocation(dateLayout, s, time.UTC)
+		if err != nil {
+			// Zero dates can not be parsed.
+			out.RawString("null")
+			return
+		}
+		out.Int64(t.Unix() / (24 * 60 * 60))
+		return
+	case mysql.TypeDatetime:
+		s, ok := col.Value.(string)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		var t time.Time
+		t, err = time.ParseInLocation(timeLayout, s, time.UTC)
+		if err != nil {
+			out.RawString("null")
+			return
+		}
+		if ft != nil && ft.GetDecimal() > 3 {
+			out.Int64(t.UnixMicro())
+		} else {
+			out.Int64(t.UnixMilli())
+		}
+		return
+	case mysql.TypeTimestamp:
+		s, ok := col.Value.(string)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		var t time.Time
+		t, err = time.ParseInLocation(timeLayout, s, c.tz)
+		if err != nil {
+			out.RawString("null")
+			return
+		}
+		layout := "2006-01-02T15:04:05"
+		if ft != nil && ft.GetDecimal() > 0 {
+			layout += "." + strings.Repeat("0", ft.GetDecimal())
+		}
+		out.String(t.UTC().Format(layout + "Z"))
+		return
+	case mysql.TypeDuration:
+		s, ok := col.Value.(string)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		var d types.Duration
+		d, _, err = types.ParseDuration(&stmtctx.StatementContext{}, s, types.MaxFsp)
+		if err == nil {
+			out.Int64(d.Duration.Microseconds())
+			return
+		}
+	case mysql.TypeEnum:
+		v, ok := col.Value.(uint64)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		// The value 0 is the empty string inserted for an invalid enum value.
+		if v == 0 {
+			out.String("")
+			return
+		}
+		if ft != nil {
+			var enum types.Enum
+			enum, err = types.ParseEnumValue(ft.GetElems(), v)
+			if err == nil {
+				out.String(enum.Name)
+				return
+			}
+		}
+	case mysql.TypeSet:
+		v, ok := col.Value.(uint64)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		if ft != nil {
+			var set types.Set
+			set, err = types.ParseSetValue(ft.GetElems(), v)
+			if err == nil {
+				out.String(set.Name)
+				return
+			}
+		}
+	case mysql.TypeBit:
+		v, ok := col.Value.(uint64)
+		if !ok {
+			out.Error = unexpectedValueTypeError(col)
+			return
+		}
+		if ft != nil && ft.GetFlen() == 1 {
+			out.Bool(v != 0)
+			return
+		}
+		n := 8
+		if ft != nil {
+			n = (ft.GetFlen() + 7) / 8
+		}
+		// Debezium writes the bits in little-endian order.
+		bits := make([]byte, n)
+		for i := range bits {
+			bits[i] = byte(v >> (8 * i))
+		}
+		out.Base64Bytes(bits)
+		return
+	}
+	if err != nil {
+		out.Error = cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+		return
+	}
+
+	switch v := col.Value.(type) {
+	case int64:
+		out.Int64(v)
+	case uint64:
+		out.Int64(int64(v))
+	case float32:
+		out.Float32(v)
+	case float64:
+		out.Float64(v)
+	case string:
+		out.String(v)
+	case []byte:
+		if col.Flag.IsBinary() {
+			out.Base64Bytes(v)
+		} else {
+			out.String(string(v))
+		}
+	default:
+		value, err := json.Marshal(v)
+		if err != nil {
+			out.Error = cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+			return
+		}
+		out.Raw(value, nil)
+	}
+}
+
+func unexpectedValueTypeError(col *model.Column) error {
+	return cerror.ErrDebeziumEncodeFailed.GenWithStack(
+		"unexpected value type %T of column %s", col.Value, col.Name)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"time"
+
+	"github.com/mailru/easyjson/jwriter"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+)
+
+// BatchEncoder encodes each row changed event into a Debezium JSON message.
+// The protocol is encode-only: there is no decoder for it, so it can not be
+// consumed by the kafka consumer.
+type BatchEncoder struct {
+	messages []*common.Message
+
+	config *common.Config
+	codec  *dbzCodec
+}
+
+// EncodeCheckpointEvent implements the RowEventEncoder interface
+func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*common.Message, error) {
+	// Debezium has no corresponding event for the resolved ts, so it is ignored.
+	return nil, nil
+}
+
+// AppendRowChangedEvent implements the RowEventEncoder interface
+func (d *BatchEncoder) AppendRowChangedEvent(
+	_ context.Context,
+	_ string,
+	e *model.RowChangedEvent,
+	callback func(),
+) error {
+	keyWriter := &jwriter.Writer{}
+	d.codec.encodeKey(e, keyWriter)
+	key, err := keyWriter.BuildBytes()
+	if err != nil {
+		return cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	if len(key) == 0 {
+		key = nil
+	}
+	valueWriter := &jwriter.Writer{}
+	d.codec.encodeValue(e, valueWriter)
+	value, err := valueWriter.BuildBytes()
+	if err != nil {
+		return cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
+	}
+	m := &common.Message{
+		Key:      key,
+		Value:    value,
+		Ts:       e.CommitTs,
+		Schema:   &e.Table.Schema,
+		Table:    &e.Table.Table,
+		Type:     model.MessageTypeRow,
+		Protocol: config.ProtocolDebezium,
+		Callback: callback,
+	}
+	m.IncRowsCount()
+
+	if m.Length() > d.config.MaxMessageBytes {
+		log.Error("Single message is too large for debezium",
+			zap.Int("maxMessageBytes", d.config.MaxMessageBytes),
+			zap.Int("length", m.Length()),
+			zap.Any("table", e.Table))
+		return cerror.ErrMessageTooLarge.GenWithStackByArgs()
+	}
+
+	d.messages = append(d.messages, m)
+	return nil
+}
+
+// EncodeDDLEvent implements the RowEventEncoder interface
+// DDL events are not sent, since Debezium sends schema changes to a
+// separate schema history topic, which is not supported yet.
+func (d *BatchEncoder) EncodeDDLEvent(_ *model.DDLEvent) (*common.Message, error) {
+	return nil, nil
+}
+
+// Build implements the RowEventEncoder interface
+func (d *BatchEncoder) Build() []*common.Message {
+	if len(d.messages) == 0 {
+		return nil
+	}
+
+	result := d.messages
+	d.messages = nil
+	return result
+}
+
+// newBatchEncoder creates a new Debezium BatchEncoder.
+func newBatchEncoder(config *common.Config, tz *time.Location) codec.RowEventEncoder {
+	return &BatchEncoder{
+		messages: make([]*common.Message, 0, 1),
+		config:   config,
+		codec: &dbzCodec{
+			config:  config,
+			tz:      tz,
+			nowFunc: time.Now,
+		},
+	}
+}
+
+type batchEncoderBuilder struct {
+	config *common.Config
+	tz     *time.Location
+}
+
+// NewBatchEncoderBuilder creates a Debezium batchEncoderBuilder.
+func NewBatchEncoderBuilder(c *common.Config) (codec.RowEventEncoderBuilder, error) {
+	// TIMESTAMP values are mounted in the time zone of the server, and
+	// they are converted to UTC when encoded.
+	tz, err := util.GetTimezone(config.GetGlobalServerConfig().TZ)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &batchEncoderBuilder{
+		config: c,
+		tz:     tz,
+	}, nil
+}
+
+// Build a `BatchEncoder`
+func (b *batchEncoderBuilder) Build() codec.RowEventEncoder {
+	return newBatchEncoder(b.config, b.tz)
+}
+
+// CleanMetrics do nothing
+func (b *batchEncoderBuilder) CleanMetrics() {}
@@ -0,0 +1,300 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+// 2023-11-30 06:38:29 UTC
+const testCommitTs = uint64(445992483946496000)
+
+// readGoldenFile returns the content of the expected message in testdata.
+func readGoldenFile(t *testing.T, name string) string {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func newTestColumns(name string) []*model.Column {
+	return []*model.Column{
+		{
+			Name:  "id",
+			Type:  mysql.TypeLong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+			Value: int64(1),
+		},
+		{
+			Name:  "name",
+			Type:  mysql.TypeVarchar,
+			Flag:  model.NullableFlag,
+			Value: []byte(name),
+		},
+		{
+			Name:  "data",
+			Type:  mysql.TypeBlob,
+			Flag:  model.BinaryFlag | model.NullableFlag,
+			Value: []byte{0x01, 0x02},
+		},
+	}
+}
+
+// newNotNullTestColumns returns the test columns with all the columns NOT NULL.
+func newNotNullTestColumns(name string) []*model.Column {
+	columns := newTestColumns(name)
+	for _, col := range columns {
+		col.Flag.UnsetIsNullable()
+	}
+	return columns
+}
+
+func newTestEncoder(deleteOnlyHandleKeyColumns bool) *BatchEncoder {
+	cfg := common.NewConfig(config.ProtocolDebezium)
+	cfg.ChangefeedID = model.DefaultChangeFeedID("test-cf")
+	cfg.DeleteOnlyHandleKeyColumns = deleteOnlyHandleKeyColumns
+	encoder := newBatchEncoder(cfg, time.UTC).(*BatchEncoder)
+	encoder.codec.nowFunc = func() time.Time {
+		return time.UnixMilli(1701326310000)
+	}
+	return encoder
+}
+
+func TestEncodeRowChangedEvent(t *testing.T) {
+	t.Parallel()
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	testCases := []struct {
+		name                       string
+		event                      *model.RowChangedEvent
+		deleteOnlyHandleKeyColumns bool
+		expectedValue              string
+	}{
+		{
+			name: "create",
+			event: &model.RowChangedEvent{
+				CommitTs: testCommitTs,
+				Table:    table,
+				Columns:  newTestColumns("alice"),
+			},
+			expectedValue: "create.json",
+		},
+		{
+			name: "update",
+			event: &model.RowChangedEvent{
+				CommitTs:   testCommitTs,
+				Table:      table,
+				PreColumns: newTestColumns("alice"),
+				Columns:    newTestColumns("bob"),
+			},
+			expectedValue: "update.json",
+		},
+		{
+			name: "delete",
+			event: &model.RowChangedEvent{
+				CommitTs:   testCommitTs,
+				Table:      table,
+				PreColumns: newTestColumns("bob"),
+			},
+			expectedValue: "delete.json",
+		},
+		{
+			name: "delete only handle key columns",
+			event: &model.RowChangedEvent{
+				CommitTs:   testCommitTs,
+				Table:      table,
+				PreColumns: newTestColumns("bob"),
+			},
+			deleteOnlyHandleKeyColumns: true,
+			expectedValue:              "delete_handle_key_only.json",
+		},
+		{
+			name: "delete only handle key columns with not null columns",
+			event: &model.RowChangedEvent{
+				CommitTs:   testCommitTs,
+				Table:      table,
+				PreColumns: newNotNullTestColumns("bob"),
+			},
+			deleteOnlyHandleKeyColumns: true,
+			expectedValue:              "delete_handle_key_only_not_null.json",
+		},
+	}
+
+	for _, tc := range testCases {
+		encoder := newTestEncoder(tc.deleteOnlyHandleKeyColumns)
+		count := 0
+		err := encoder.AppendRowChangedEvent(context.Background(), "", tc.event, func() { count++ })
+		require.NoError(t, err, tc.name)
+
+		messages := encoder.Build()
+		require.Len(t, messages, 1, tc.name)
+		require.Equal(t, 1, messages[0].GetRowsCount(), tc.name)
+		require.Equal(t, config.ProtocolDebezium, messages[0].Protocol, tc.name)
+		require.JSONEq(t, readGoldenFile(t, "key.json"), string(messages[0].Key), tc.name)
+		require.JSONEq(t, readGoldenFile(t, tc.expectedValue), string(messages[0].Value), tc.name)
+		messages[0].Callback()
+		require.Equal(t, 1, count, tc.name)
+		require.Nil(t, encoder.Build(), tc.name)
+	}
+}
+
+func newTestFieldType(tp byte, flen int, decimal int, elems ...string) *types.FieldType {
+	ft := types.NewFieldType(tp)
+	ft.SetFlen(flen)
+	ft.SetDecimal(decimal)
+	ft.SetElems(elems)
+	return ft
+}
+
+func TestEncodeColumnTypes(t *testing.T) {
+	t.Parallel()
+
+	columns := []*model.Column{
+		{
+			Name:  "id",
+			Type:  mysql.TypeLonglong,
+			Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag | model.UnsignedFlag,
+			Value: uint64(18446744073709551615),
+		},
+		{Name: "tiny", Type: mysql.TypeTiny, Flag: model.UnsignedFlag, Value: uint64(200)},
+		{Name: "float", Type: mysql.TypeFloat, Value: float32(1.5)},
+		{Name: "decimal", Type: mysql.TypeNewDecimal, Value: "1.23"},
+		{Name: "enum", Type: mysql.TypeEnum, Value: uint64(2)},
+		{Name: "invalid_enum", Type: mysql.TypeEnum, Value: uint64(0)},
+		{Name: "set", Type: mysql.TypeSet, Value: uint64(5)},
+		{Name: "date", Type: mysql.TypeDate, Value: "2023-11-30"},
+		{Name: "zero_date", Type: mysql.TypeDate, Flag: model.NullableFlag, Value: "0000-00-00"},
+		{Name: "time", Type: mysql.TypeDuration, Value: "-01:00:00.5"},
+		{Name: "datetime", Type: mysql.TypeDatetime, Value: "2023-11-30 06:38:29"},
+		{Name: "datetime6", Type: mysql.TypeDatetime, Value: "2023-11-30 06:38:29.123456"},
+		{Name: "timestamp", Type: mysql.TypeTimestamp, Value: "2023-11-30 14:38:29.120"},
+		{Name: "year", Type: mysql.TypeYear, Value: int64(2023)},
+		{Name: "json", Type: mysql.TypeJSON, Value: `{"a": 1}`},
+		{Name: "bit1", Type: mysql.TypeBit, Value: uint64(1)},
+		{Name: "bit10", Type: mysql.TypeBit, Value: uint64(0x201)},
+	}
+	fieldTypes := []*types.FieldType{
+		newTestFieldType(mysql.TypeLonglong, 20, 0),
+		newTestFieldType(mysql.TypeTiny, 3, 0),
+		newTestFieldType(mysql.TypeFloat, 12, types.UnspecifiedLength),
+		newTestFieldType(mysql.TypeNewDecimal, 10, 2),
+		newTestFieldType(mysql.TypeEnum, types.UnspecifiedLength, 0, "a", "b", "c"),
+		newTestFieldType(mysql.TypeEnum, types.UnspecifiedLength, 0, "a", "b", "c"),
+		newTestFieldType(mysql.TypeSet, types.UnspecifiedLength, 0, "a", "b", "c"),
+		newTestFieldType(mysql.TypeDate, 10, 0),
+		newTestFieldType(mysql.TypeDate, 10, 0),
+		newTestFieldType(mysql.TypeDuration, 12, 1),
+		newTestFieldType(mysql.TypeDatetime, 19, 0),
+		newTestFieldType(mysql.TypeDatetime, 26, 6),
+		newTestFieldType(mysql.TypeTimestamp, 23, 3),
+		newTestFieldType(mysql.TypeYear, 4, 0),
+		newTestFieldType(mysql.TypeJSON, types.UnspecifiedLength, 0),
+		newTestFieldType(mysql.TypeBit, 1, 0),
+		newTestFieldType(mysql.TypeBit, 10, 0),
+	}
+	colInfos := make([]rowcodec.ColInfo, 0, len(fieldTypes))
+	for i, ft := range fieldTypes {
+		colInfos = append(colInfos, rowcodec.ColInfo{ID: int64(i + 1), Ft: ft})
+	}
+
+	encoder := newTestEncoder(false)
+	// TIMESTAMP values are mounted in the server time zone.
+	encoder.codec.tz = time.FixedZone("UTC+8", 8*60*60)
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs: testCommitTs,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns:  columns,
+		ColInfos: colInfos,
+	}, nil)
+	require.NoError(t, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.JSONEq(t, readGoldenFile(t, "column_types_key.json"), string(messages[0].Key))
+	require.JSONEq(t, readGoldenFile(t, "column_types.json"), string(messages[0].Value))
+}
+
+func TestEncodeRowChangedEventWithoutHandleKey(t *testing.T) {
+	t.Parallel()
+
+	encoder := newTestEncoder(false)
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+		CommitTs: testCommitTs,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{{
+			Name: "a", Type: mysql.TypeLonglong, Flag: model.NullableFlag, Value: int64(1),
+		}},
+	}, nil)
+	require.NoError(t, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Nil(t, messages[0].Key)
+}
+
+func TestEncodeDDLAndCheckpointEvent(t *testing.T) {
+	t.Parallel()
+
+	encoder := newTestEncoder(false)
+	msg, err := encoder.EncodeCheckpointEvent(testCommitTs)
+	require.NoError(t, err)
+	require.Nil(t, msg)
+
+	msg, err = encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs: testCommitTs,
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{Schema: "test", Table: "t"},
+			TableInfo: &timodel.TableInfo{},
+		},
+		Query: "create table t(id int primary key)",
+		Type:  timodel.ActionCreateTable,
+	})
+	require.NoError(t, err)
+	require.Nil(t, msg)
+}
+
+func TestEncodeUnexpectedValueType(t *testing.T) {
+	t.Parallel()
+
+	// ENUM and SET values are mounted as their uint64 index, so a value left
+	// as its name can not be encoded.
+	for _, col := range []*model.Column{
+		{Name: "enum", Type: mysql.TypeEnum, Value: "b"},
+		{Name: "set", Type: mysql.TypeSet, Value: "a,c"},
+	} {
+		encoder := newTestEncoder(false)
+		ft := newTestFieldType(col.Type, types.UnspecifiedLength, 0, "a", "b", "c")
+		err := encoder.AppendRowChangedEvent(context.Background(), "", &model.RowChangedEvent{
+			CommitTs: testCommitTs,
+			Table:    &model.TableName{Schema: "test", Table: "t"},
+			Columns:  []*model.Column{col},
+			ColInfos: []rowcodec.ColInfo{{ID: 1, Ft: ft}},
+		}, nil)
+		require.True(t, cerror.ErrDebeziumEncodeFailed.Equal(err), col.Name)
+		require.Nil(t, encoder.Build(), col.Name)
+	}
+}
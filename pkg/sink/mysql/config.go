@@ -53,6 +53,12 @@ const (
 	maxWorkerCount = 1024
 	// The upper limit of max txn rows.
 	maxMaxTxnRow = 2048
+	// defaultMaxTxnSize is the default max approximate size in bytes of rows
+	// in a transaction, 0 means there is no limit.
+	defaultMaxTxnSize = 0
+	// The upper limit of max txn size(100MB), which is the default
+	// txn-total-size-limit of TiDB.
+	maxMaxTxnSize = 100 * 1024 * 1024
 	// The upper limit of max multi update rows in a single SQL.
 	maxMaxMultiUpdateRowCount = 256
 	// The upper limit of max multi update row size(8KB).
@@ -82,6 +88,7 @@ const (
 type urlConfig struct {
 	WorkerCount                  *int    `form:"worker-count"`
 	MaxTxnRow                    *int    `form:"max-txn-row"`
+	MaxTxnSize                   *int    `form:"max-txn-size"`
 	MaxMultiUpdateRowSize        *int    `form:"max-multi-update-row-size"`
 	MaxMultiUpdateRowCount       *int    `form:"max-multi-update-row"`
 	TiDBTxnMode                  *string `form:"tidb-txn-mode"`
@@ -102,6 +109,7 @@ type urlConfig struct {
 type Config struct {
 	WorkerCount            int
 	MaxTxnRow              int
+	MaxTxnSize             int
 	MaxMultiUpdateRowCount int
 	MaxMultiUpdateRowSize  int
 	tidbTxnMode            string
@@ -129,6 +137,7 @@ func NewConfig() *Config {
 	return &Config{
 		WorkerCount:            DefaultWorkerCount,
 		MaxTxnRow:              DefaultMaxTxnRow,
+		MaxTxnSize:             defaultMaxTxnSize,
 		MaxMultiUpdateRowCount: defaultMaxMultiUpdateRowCount,
 		MaxMultiUpdateRowSize:  defaultMaxMultiUpdateRowSize,
 		tidbTxnMode:            defaultTiDBTxnMode,
@@ -171,6 +180,9 @@ func (c *Config) Apply(
 	if err = getMaxTxnRow(urlParameter, &c.MaxTxnRow); err != nil {
 		return err
 	}
+	if err = getMaxTxnSize(urlParameter, &c.MaxTxnSize); err != nil {
+		return err
+	}
 	if err = getMaxMultiUpdateRowCount(urlParameter, &c.MaxMultiUpdateRowCount); err != nil {
 		return err
 	}
@@ -213,6 +225,7 @@ func mergeConfig(
 		mConfig := replicaConfig.Sink.MySQLConfig
 		dest.WorkerCount = mConfig.WorkerCount
 		dest.MaxTxnRow = mConfig.MaxTxnRow
+		dest.MaxTxnSize = mConfig.MaxTxnSize
 		dest.MaxMultiUpdateRowCount = mConfig.MaxMultiUpdateRowCount
 		dest.MaxMultiUpdateRowSize = mConfig.MaxMultiUpdateRowSize
 		dest.TiDBTxnMode = mConfig.TiDBTxnMode
@@ -271,6 +284,26 @@ func getMaxTxnRow(config *urlConfig, maxTxnRow *int) error {
 	return nil
 }
 
+func getMaxTxnSize(config *urlConfig, maxTxnSize *int) error {
+	if config.MaxTxnSize == nil {
+		return nil
+	}
+
+	c := *config.MaxTxnSize
+	if c < 0 {
+		return cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			fmt.Errorf("invalid max-txn-size %d, "+
+				"which must be greater than or equal to 0", c))
+	}
+	if c > maxMaxTxnSize {
+		log.Warn("max-txn-size too large",
+			zap.Int("original", c), zap.Int("override", maxMaxTxnSize))
+		c = maxMaxTxnSize
+	}
+	*maxTxnSize = c
+	return nil
+}
+
 func getMaxMultiUpdateRowCount(values *urlConfig, maxMultiUpdateRow *int) error {
 	if values.MaxMultiUpdateRowCount == nil {
 		return nil
@@ -184,6 +184,7 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	expected := NewConfig()
 	expected.WorkerCount = 64
 	expected.MaxTxnRow = 20
+	expected.MaxTxnSize = 1024
 	expected.MaxMultiUpdateRowCount = 80
 	expected.MaxMultiUpdateRowSize = 512
 	expected.SafeMode = false
@@ -191,6 +192,7 @@ func TestApplySinkURIParamsToConfig(t *testing.T) {
 	expected.tidbTxnMode = "pessimistic"
 	expected.CachePrepStmts = true
 	uriStr := "mysql://127.0.0.1:3306/?worker-count=64&max-txn-row=20" +
+		"&max-txn-size=1024" +
 		"&max-multi-update-row=80&max-multi-update-row-size=512" +
 		"&safe-mode=false" +
 		"&tidb-txn-mode=pessimistic" +
@@ -220,6 +222,11 @@ func TestParseSinkURIOverride(t *testing.T) {
 		checker: func(sp *Config) {
 			require.EqualValues(t, sp.MaxTxnRow, maxMaxTxnRow)
 		},
+	}, {
+		uri: "mysql://127.0.0.1:3306/?max-txn-size=2147483648", // int32 max
+		checker: func(sp *Config) {
+			require.EqualValues(t, sp.MaxTxnSize, maxMaxTxnSize)
+		},
 	}, {
 		uri: "mysql://127.0.0.1:3306/?max-multi-update-row=2147483648", // int32 max
 		checker: func(sp *Config) {
@@ -271,6 +278,8 @@ func TestParseSinkURIBadQueryString(t *testing.T) {
 		"mysql://127.0.0.1:3306/?max-txn-row=not-number",
 		"mysql://127.0.0.1:3306/?max-txn-row=-1",
 		"mysql://127.0.0.1:3306/?max-txn-row=0",
+		"mysql://127.0.0.1:3306/?max-txn-size=not-number",
+		"mysql://127.0.0.1:3306/?max-txn-size=-1",
 		"mysql://127.0.0.1:3306/?ssl-ca=only-ca-exists",
 		"mysql://127.0.0.1:3306/?safe-mode=not-bool",
 		"mysql://127.0.0.1:3306/?time-zone=badtz",
@@ -415,6 +424,7 @@ func TestMergeConfig(t *testing.T) {
 	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
 		WorkerCount:                  aws.Int(13),
 		MaxTxnRow:                    aws.Int(100),
+		MaxTxnSize:                   aws.Int(101),
 		MaxMultiUpdateRowSize:        aws.Int(102),
 		MaxMultiUpdateRowCount:       aws.Int(103),
 		TiDBTxnMode:                  aws.String("pessimistic"),
@@ -431,6 +441,7 @@ func TestMergeConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 13, c.WorkerCount)
 	require.Equal(t, 100, c.MaxTxnRow)
+	require.Equal(t, 101, c.MaxTxnSize)
 	require.Equal(t, 102, c.MaxMultiUpdateRowSize)
 	require.Equal(t, 103, c.MaxMultiUpdateRowCount)
 	require.Equal(t, "pessimistic", c.tidbTxnMode)
@@ -445,6 +456,7 @@ func TestMergeConfig(t *testing.T) {
 	uri = "mysql://topic?" +
 		"worker-count=13&" +
 		"max-txn-row=100&" +
+		"max-txn-size=101&" +
 		"max-multi-update-row-size=102&" +
 		"max-multi-update-row=103&" +
 		"tidb-txn-mode=pessimistic&" +
@@ -461,6 +473,7 @@ func TestMergeConfig(t *testing.T) {
 	replicaConfig.Sink.MySQLConfig = &config.MySQLConfig{
 		WorkerCount:                  aws.Int(11),
 		MaxTxnRow:                    aws.Int(130),
+		MaxTxnSize:                   aws.Int(131),
 		MaxMultiUpdateRowSize:        aws.Int(142),
 		MaxMultiUpdateRowCount:       aws.Int(153),
 		TiDBTxnMode:                  aws.String("optimistic"),
@@ -477,6 +490,7 @@ func TestMergeConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 13, c.WorkerCount)
 	require.Equal(t, 100, c.MaxTxnRow)
+	require.Equal(t, 101, c.MaxTxnSize)
 	require.Equal(t, 102, c.MaxMultiUpdateRowSize)
 	require.Equal(t, 103, c.MaxMultiUpdateRowCount)
 	require.Equal(t, "pessimistic", c.tidbTxnMode)